@@ -1,47 +1,201 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"pi/pi"
+	"strings"
 	"syscall"
+	"time"
 )
 
-func Calculate(numWorkers int) (float64, error) {
-	calc, err := pi.New(numWorkers)
+// go run main.go -n 8 -timeout 2s
+// go run main.go -n 8 -terms 100000000
+// go run main.go -n 8 --precision 1e-9
+// go run main.go -n 4 --algorithm machin --precision 1e-15
+// go run main.go -n 4 --digits 1000
+// go run main.go -n 8 -timeout 5s -progress -v
+// go run main.go -n 8 -montecarlo -seed 42 -samples 10000000
+
+func NewCalculator(series pi.Series, numWorkers int, verbose, progress bool) (*pi.Calculator, error) {
+	calc, err := pi.NewWithSeries(numWorkers, series)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	if verbose {
+		calc.SetLogger(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	}
+
+	if progress {
+		calc.OnProgress(200*time.Millisecond, printProgress)
+	}
+
+	return calc, nil
+}
+
+func printProgress(p pi.Progress) {
+	fmt.Fprintf(os.Stderr, "\rTerms: %d, estimate: %.15f", p.Terms, p.Estimate)
+}
+
+// stopReason says why ctx ended, for the message printed when a
+// calculation is cut short.
+func stopReason(ctx context.Context) string {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "Deadline reached"
+	}
+	return "Stop signal received"
+}
+
+func Calculate(ctx context.Context, calc *pi.Calculator, terms int) (float64, error) {
+	if terms > 0 {
+		stop := context.AfterFunc(ctx, func() {
+			fmt.Println("\n" + stopReason(ctx))
+			calc.Stop()
+		})
+		defer stop()
+
+		return calc.CalculateTerms(terms)
 	}
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	result, err := calc.CalculateContext(ctx)
+	if ctx.Err() != nil {
+		fmt.Println("\n" + stopReason(ctx))
+	}
+
+	return result, err
+}
 
-	resultChan := make(chan float64)
-	go func() {
-		result := calc.Calculate()
-		resultChan <- result
-	}()
-	
-	go func() {
-		<-sigChan
-		fmt.Println("\nStop signal received")
+func CalculatePrecision(ctx context.Context, calc *pi.Calculator, precision float64) (pi.Estimate, error) {
+	stop := context.AfterFunc(ctx, func() {
+		fmt.Println("\n" + stopReason(ctx))
 		calc.Stop()
-	}()
+	})
+	defer stop()
+
+	return calc.CalculatePrecision(precision)
+}
+
+func CalculateMonteCarlo(ctx context.Context, mc *pi.MonteCarlo, samples int) (pi.MonteCarloEstimate, error) {
+	if samples > 0 {
+		stop := context.AfterFunc(ctx, func() {
+			fmt.Println("\n" + stopReason(ctx))
+			mc.Stop()
+		})
+		defer stop()
+
+		return mc.CalculateSamples(samples)
+	}
+
+	estimate, err := mc.CalculateContext(ctx)
+	if ctx.Err() != nil {
+		fmt.Println("\n" + stopReason(ctx))
+	}
 
-	return <-resultChan, nil
+	return estimate, err
 }
 
 func main() {
 	numWorkers := flag.Int("n", 4, "Number of goroutines")
+	timeout := flag.Duration("timeout", 0, "Stop after this duration (0 runs until interrupted)")
+	terms := flag.Int("terms", 0, "Sum exactly this many terms (0 runs until stopped)")
+	precision := flag.Float64("precision", 0, "Sum enough terms to be within this distance of pi (0 disables)")
+	numDigits := flag.Int("digits", 0, "Print this many exact digits of pi using arbitrary precision (0 disables)")
+	algorithm := flag.String("algorithm", "leibniz",
+		fmt.Sprintf("Series to sum, one of %s", strings.Join(pi.SeriesNames(), ", ")))
+	monteCarlo := flag.Bool("montecarlo", false, "Estimate pi by Monte Carlo sampling instead of summing a series")
+	seed := flag.Uint64("seed", 1, "Seed for the Monte Carlo random streams")
+	samples := flag.Int("samples", 0, "Draw exactly this many Monte Carlo samples (0 runs until stopped)")
+	progress := flag.Bool("progress", false, "Show a live progress line on stderr")
+	verbose := flag.Bool("v", false, "Log worker events to stderr")
 	flag.Parse()
 
-	piValue, err := Calculate(*numWorkers)
+	series, err := pi.SeriesByName(*algorithm)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if *timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, *timeout)
+		defer cancelTimeout()
+	}
+
+	if *numDigits > 0 {
+		calc, err := pi.New(*numWorkers)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		digits, err := calc.Digits(ctx, *numDigits)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(digits)
+		return
+	}
+
+	if *monteCarlo {
+		mc, err := pi.NewMonteCarlo(*numWorkers, *seed)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *verbose {
+			mc.SetLogger(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+		}
+
+		// A stopped run still reports the samples drawn so far.
+		estimate, err := CalculateMonteCarlo(ctx, mc, *samples)
+		if err != nil && !errors.Is(err, pi.ErrStopped) {
+			log.Fatal(err)
+		}
+
+		low, high, err := estimate.ConfidenceInterval(0.95)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Approximately pi is: %.6f, 95%% CI [%.6f, %.6f] (%d samples)\n",
+			estimate.Value, low, high, estimate.Samples)
+		return
+	}
+
+	calc, err := NewCalculator(series, *numWorkers, *verbose, *progress)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if *precision > 0 {
+		estimate, err := CalculatePrecision(ctx, calc, *precision)
+		if *progress {
+			fmt.Fprintln(os.Stderr)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Approximately pi is: %.15f ± %g (%d terms)\n",
+			estimate.Value, estimate.ErrorBound, estimate.Terms)
+		return
+	}
+
+	piValue, err := Calculate(ctx, calc, *terms)
+	if *progress {
+		fmt.Fprintln(os.Stderr)
+	}
+	if err != nil && !errors.Is(err, pi.ErrStopped) {
+		log.Fatal(err)
+	}
+
 	fmt.Println("Approximately pi is:", piValue)
 }
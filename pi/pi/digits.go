@@ -0,0 +1,199 @@
+package pi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"runtime"
+)
+
+// Each Chudnovsky term contributes log10(640320³/(24*6*2*6)) ≈ 14.18 digits.
+const chudnovskyDigitsPerTerm = 14.181647462725477
+
+// Extra digits computed beyond the requested ones so that truncation is exact.
+const guardDigits = 10
+
+var (
+	chudnovskyC3    = new(big.Int).Div(new(big.Int).Exp(big.NewInt(640320), big.NewInt(3), nil), big.NewInt(24))
+	chudnovskyA     = big.NewInt(13591409)
+	chudnovskyB     = big.NewInt(545140134)
+	chudnovskyScale = big.NewInt(426880)
+)
+
+// chudnovskySplit holds the binary splitting state P(a,b), Q(a,b), T(a,b)
+// for the Chudnovsky terms a..b-1.
+type chudnovskySplit struct {
+	p, q, t *big.Int
+}
+
+// splitPart is the split of the range of terms given to a single worker.
+type splitPart struct {
+	worker int
+	split  chudnovskySplit
+	err    error
+}
+
+// Digits returns π with n digits after the decimal point, truncated, using
+// all available CPUs.
+func Digits(ctx context.Context, n int) (string, error) {
+	return digits(ctx, n, runtime.GOMAXPROCS(0), slog.New(slog.DiscardHandler), nil)
+}
+
+// Digits is like the package-level Digits but splits the work across the
+// calculator's workers, reporting to its logger. Calling Stop cuts the
+// computation short with ErrStopped.
+func (c *Calculator) Digits(ctx context.Context, n int) (string, error) {
+	return digits(ctx, n, c.numWorkers, c.logger, c.stopping)
+}
+
+// digits computes n digits of π, also giving up once stopped, if not nil,
+// reports true.
+func digits(ctx context.Context, n, numWorkers int, logger *slog.Logger, stopped func() bool) (string, error) {
+	if n < 0 {
+		return "", fmt.Errorf("number of digits must be non-negative, got %d", n)
+	}
+
+	precision := n + guardDigits
+	terms := int(math.Ceil(float64(precision)/chudnovskyDigitsPerTerm)) + 1
+
+	split, err := parallelSplit(ctx, terms, numWorkers, logger, stopped)
+	if err != nil {
+		return "", err
+	}
+
+	// π = 426880 * sqrt(10005) * Q / T, evaluated in fixed point with
+	// precision decimal digits.
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+	sqrt := new(big.Int).Mul(big.NewInt(10005), new(big.Int).Mul(scale, scale))
+	sqrt.Sqrt(sqrt)
+
+	num := new(big.Int).Mul(chudnovskyScale, sqrt)
+	num.Mul(num, split.q)
+	pi := num.Quo(num, split.t)
+
+	s := pi.String()
+	s = s[:len(s)-guardDigits]
+	if n == 0 {
+		return s, nil
+	}
+
+	return s[:1] + "." + s[1:], nil
+}
+
+// parallelSplit divides the terms into contiguous ranges, splits each range
+// in a worker of its own and merges the results in order.
+func parallelSplit(ctx context.Context, terms, numWorkers int, logger *slog.Logger, stopped func() bool) (chudnovskySplit, error) {
+	if err := ctx.Err(); err != nil {
+		return chudnovskySplit{}, err
+	}
+
+	pool, err := newWorkerPool[splitPart](min(max(numWorkers, 1), terms))
+	if err != nil {
+		return chudnovskySplit{}, err
+	}
+	pool.SetLogger(logger)
+	defer context.AfterFunc(ctx, pool.Stop)()
+
+	stopping := func() bool {
+		return pool.stopping() || stopped != nil && stopped()
+	}
+
+	pool.start(func(id int) {
+		a := id * terms / pool.numWorkers
+		b := (id + 1) * terms / pool.numWorkers
+
+		pool.logger.Info("worker started", "worker", id, "terms", b-a)
+		split, err := binarySplit(stopping, a, b)
+		if err != nil {
+			pool.logger.Info("worker received stop signal", "worker", id)
+		} else {
+			pool.logger.Info("worker finished", "worker", id)
+		}
+		pool.results <- splitPart{worker: id, split: split, err: err}
+	})
+	parts := pool.collect(func(p splitPart) int { return p.worker })
+
+	for _, part := range parts {
+		if part.err != nil {
+			if err := ctx.Err(); err != nil {
+				return chudnovskySplit{}, err
+			}
+			return chudnovskySplit{}, part.err
+		}
+	}
+
+	result := parts[0].split
+	for _, part := range parts[1:] {
+		result = mergeSplit(result, part.split)
+	}
+
+	return result, nil
+}
+
+// binarySplit splits the terms a..b-1, returning ErrStopped as soon as
+// stopping reports true.
+func binarySplit(stopping func() bool, a, b int) (chudnovskySplit, error) {
+	if stopping() {
+		return chudnovskySplit{}, ErrStopped
+	}
+
+	if b-a == 1 {
+		return chudnovskyTerm(a), nil
+	}
+
+	m := (a + b) / 2
+
+	left, err := binarySplit(stopping, a, m)
+	if err != nil {
+		return chudnovskySplit{}, err
+	}
+
+	right, err := binarySplit(stopping, m, b)
+	if err != nil {
+		return chudnovskySplit{}, err
+	}
+
+	return mergeSplit(left, right), nil
+}
+
+func chudnovskyTerm(a int) chudnovskySplit {
+	if a == 0 {
+		return chudnovskySplit{
+			p: big.NewInt(1),
+			q: big.NewInt(1),
+			t: new(big.Int).Set(chudnovskyA),
+		}
+	}
+
+	k := big.NewInt(int64(a))
+
+	p := big.NewInt(int64(6*a - 5))
+	p.Mul(p, big.NewInt(int64(2*a-1)))
+	p.Mul(p, big.NewInt(int64(6*a-1)))
+
+	q := new(big.Int).Mul(k, k)
+	q.Mul(q, k)
+	q.Mul(q, chudnovskyC3)
+
+	t := new(big.Int).Mul(chudnovskyB, k)
+	t.Add(t, chudnovskyA)
+	t.Mul(t, p)
+	if a%2 == 1 {
+		t.Neg(t)
+	}
+
+	return chudnovskySplit{p: p, q: q, t: t}
+}
+
+// mergeSplit combines the splits of adjacent ranges [a,m) and [m,b).
+func mergeSplit(left, right chudnovskySplit) chudnovskySplit {
+	p := new(big.Int).Mul(left.p, right.p)
+	q := new(big.Int).Mul(left.q, right.q)
+
+	t := new(big.Int).Mul(left.t, right.q)
+	t.Add(t, new(big.Int).Mul(left.p, right.t))
+
+	return chudnovskySplit{p: p, q: q, t: t}
+}
@@ -0,0 +1,85 @@
+package pi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const piPrefix = "3.1415926535897932384626433832795028841971693993751058209749445923078164062862089986280348253421170679" +
+	"8214808651328230664709384460955058223172535940812848111745028410270193852110555964462294895493038196"
+
+func TestDigits(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		expected string
+	}{
+		{name: "integer part only", n: 0, expected: "3"},
+		{name: "one digit", n: 1, expected: "3.1"},
+		{name: "float64 precision", n: 15, expected: "3.141592653589793"},
+		{name: "beyond float64", n: 30, expected: piPrefix[:32]},
+		{name: "two hundred digits", n: 200, expected: piPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Digits(context.Background(), tt.n)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestDigitsPrefixes(t *testing.T) {
+	for n := 1; n <= 200; n++ {
+		result, err := Digits(context.Background(), n)
+		require.NoError(t, err)
+		require.Equal(t, piPrefix[:n+2], result, "n=%d", n)
+	}
+}
+
+func TestCalculator_Digits(t *testing.T) {
+	for _, numWorkers := range []int{1, 2, 3, 7, 64} {
+		calc, err := New(numWorkers)
+		require.NoError(t, err)
+
+		result, err := calc.Digits(context.Background(), 200)
+		require.NoError(t, err)
+		require.Equal(t, piPrefix, result, "numWorkers=%d", numWorkers)
+	}
+}
+
+func TestDigitsLarge(t *testing.T) {
+	small, err := Digits(context.Background(), 200)
+	require.NoError(t, err)
+
+	large, err := Digits(context.Background(), 5000)
+	require.NoError(t, err)
+	require.Len(t, large, 5002)
+	require.Equal(t, small, large[:202])
+}
+
+func TestDigitsInvalid(t *testing.T) {
+	_, err := Digits(context.Background(), -1)
+	require.Error(t, err)
+	require.Equal(t, "number of digits must be non-negative, got -1", err.Error())
+}
+
+func TestDigitsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Digits(ctx, 1000)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCalculator_DigitsStopped(t *testing.T) {
+	calc, err := New(4)
+	require.NoError(t, err)
+	calc.Stop()
+
+	_, err = calc.Digits(context.Background(), 1000)
+	require.ErrorIs(t, err, ErrStopped)
+}
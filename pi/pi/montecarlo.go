@@ -0,0 +1,167 @@
+package pi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// MonteCarlo estimates π by sampling points in the unit square and counting
+// those that fall inside the quarter circle. Worker i draws from its own PCG
+// stream seeded with (seed, i), so for a given seed and worker count a fixed
+// number of samples always yields the same estimate.
+type MonteCarlo struct {
+	*workerPool[MonteCarloPartial]
+	seed uint64
+}
+
+// MonteCarloPartial is the share of samples drawn by a single worker.
+type MonteCarloPartial struct {
+	Worker  int
+	Samples int
+	Hits    int
+}
+
+// MonteCarloEstimate is an estimate of π with its standard error.
+type MonteCarloEstimate struct {
+	Value   float64
+	Samples int
+	Hits    int
+	StdErr  float64
+}
+
+func NewMonteCarlo(numWorkers int, seed uint64) (*MonteCarlo, error) {
+	pool, err := newWorkerPool[MonteCarloPartial](numWorkers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MonteCarlo{workerPool: pool, seed: seed}, nil
+}
+
+// worker draws samples until stopped, or until limit samples have been
+// drawn if limit is non-negative.
+func (m *MonteCarlo) worker(id, limit int) {
+	rng := rand.New(rand.NewPCG(m.seed, uint64(id)))
+	result := MonteCarloPartial{Worker: id}
+
+	m.logger.Info("worker started", "worker", id)
+
+	for limit < 0 || result.Samples < limit {
+		if m.stopping() {
+			m.logger.Info("worker received stop signal", "worker", id, "samples", result.Samples)
+			m.results <- result
+			return
+		}
+
+		x, y := rng.Float64(), rng.Float64()
+		if x*x+y*y <= 1 {
+			result.Hits++
+		}
+		result.Samples++
+	}
+
+	m.logger.Info("worker finished", "worker", id, "samples", result.Samples)
+	m.results <- result
+}
+
+func (m *MonteCarlo) Start() {
+	m.start(func(int) int { return -1 })
+}
+
+func (m *MonteCarlo) start(limit func(id int) int) {
+	m.workerPool.start(func(id int) {
+		m.worker(id, limit(id))
+	})
+}
+
+func (m *MonteCarlo) Calculate() MonteCarloEstimate {
+	m.Start()
+	return m.collect()
+}
+
+// CalculateContext samples until ctx is done or Stop is called and returns
+// the estimate accumulated so far, or ErrNoTerms if the workers were
+// stopped before drawing any sample. Where ctx stopped them, the error
+// also wraps ctx.Err().
+func (m *MonteCarlo) CalculateContext(ctx context.Context) (MonteCarloEstimate, error) {
+	defer context.AfterFunc(ctx, m.Stop)()
+
+	estimate := m.Calculate()
+	if estimate.Samples == 0 {
+		if err := ctx.Err(); err != nil {
+			return estimate, fmt.Errorf("%w: %w", ErrNoTerms, err)
+		}
+		return estimate, ErrNoTerms
+	}
+	return estimate, nil
+}
+
+// CalculateSamples draws exactly samples points, split as evenly as
+// possible across the workers. Calling Stop cuts the computation short, in
+// which case the estimate from the samples drawn so far comes with
+// ErrStopped.
+func (m *MonteCarlo) CalculateSamples(samples int) (MonteCarloEstimate, error) {
+	if samples < 0 {
+		return MonteCarloEstimate{}, fmt.Errorf("number of samples must be non-negative, got %d", samples)
+	}
+
+	m.start(func(id int) int {
+		limit := samples / m.numWorkers
+		if id < samples%m.numWorkers {
+			limit++
+		}
+		return limit
+	})
+
+	estimate := m.collect()
+	if m.stopped.Load() {
+		return estimate, ErrStopped
+	}
+	return estimate, nil
+}
+
+// Partials returns the per-worker counts of the last finished calculation,
+// ordered by worker id.
+func (m *MonteCarlo) Partials() []MonteCarloPartial {
+	return m.lastResults()
+}
+
+func (m *MonteCarlo) collect() MonteCarloEstimate {
+	partials := m.workerPool.collect(func(p MonteCarloPartial) int { return p.Worker })
+
+	var samples, hits int
+	for _, p := range partials {
+		samples += p.Samples
+		hits += p.Hits
+	}
+
+	return newMonteCarloEstimate(samples, hits)
+}
+
+func newMonteCarloEstimate(samples, hits int) MonteCarloEstimate {
+	if samples == 0 {
+		return MonteCarloEstimate{StdErr: math.Inf(1)}
+	}
+
+	p := float64(hits) / float64(samples)
+
+	return MonteCarloEstimate{
+		Value:   4 * p,
+		Samples: samples,
+		Hits:    hits,
+		StdErr:  4 * math.Sqrt(p*(1-p)/float64(samples)),
+	}
+}
+
+// ConfidenceInterval returns the normal-approximation interval around the
+// estimate that holds π with the given probability, e.g. 0.95.
+func (e MonteCarloEstimate) ConfidenceInterval(level float64) (low, high float64, err error) {
+	if !(level > 0 && level < 1) {
+		return 0, 0, fmt.Errorf("confidence level must be in (0, 1), got %g", level)
+	}
+
+	z := math.Sqrt2 * math.Erfinv(level)
+	return e.Value - z*e.StdErr, e.Value + z*e.StdErr, nil
+}
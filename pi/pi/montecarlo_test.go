@@ -0,0 +1,198 @@
+package pi
+
+import (
+	"context"
+	"math"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMonteCarlo(t *testing.T) {
+	mc, err := NewMonteCarlo(4, 42)
+	require.NoError(t, err)
+	require.NotNil(t, mc)
+	require.Equal(t, 4, mc.numWorkers)
+	require.Equal(t, uint64(42), mc.seed)
+
+	mc, err = NewMonteCarlo(0, 42)
+	require.Error(t, err)
+	require.Nil(t, mc)
+	require.Equal(t, "number of workers must be positive, got 0", err.Error())
+}
+
+func TestMonteCarlo_CalculateSamples(t *testing.T) {
+	tests := []struct {
+		name       string
+		numWorkers int
+		samples    int
+	}{
+		{name: "single worker", numWorkers: 1, samples: 100000},
+		{name: "multiple workers", numWorkers: 4, samples: 1000000},
+		{name: "uneven split", numWorkers: 7, samples: 1000003},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc, err := NewMonteCarlo(tt.numWorkers, 1)
+			require.NoError(t, err)
+
+			estimate, err := mc.CalculateSamples(tt.samples)
+			require.NoError(t, err)
+			require.Equal(t, tt.samples, estimate.Samples)
+			require.InDelta(t, math.Pi, estimate.Value, 5*estimate.StdErr)
+
+			low, high, err := estimate.ConfidenceInterval(0.9999)
+			require.NoError(t, err)
+			require.Less(t, low, math.Pi)
+			require.Greater(t, high, math.Pi)
+		})
+	}
+}
+
+func TestMonteCarlo_Reproducible(t *testing.T) {
+	run := func(numWorkers int, seed uint64) MonteCarloEstimate {
+		mc, err := NewMonteCarlo(numWorkers, seed)
+		require.NoError(t, err)
+
+		estimate, err := mc.CalculateSamples(100000)
+		require.NoError(t, err)
+		return estimate
+	}
+
+	first := run(4, 7)
+	require.Equal(t, first, run(4, 7))
+	require.NotEqual(t, first, run(4, 8))
+	require.NotEqual(t, first, run(3, 7))
+}
+
+func TestMonteCarlo_Partials(t *testing.T) {
+	mc, err := NewMonteCarlo(3, 1)
+	require.NoError(t, err)
+
+	estimate, err := mc.CalculateSamples(10)
+	require.NoError(t, err)
+
+	partials := mc.Partials()
+	require.Len(t, partials, 3)
+
+	var hits int
+	for i, p := range partials {
+		require.Equal(t, i, p.Worker)
+		require.Equal(t, []int{4, 3, 3}[i], p.Samples)
+		hits += p.Hits
+	}
+	require.Equal(t, estimate.Hits, hits)
+}
+
+func TestMonteCarlo_CalculateSamplesInvalid(t *testing.T) {
+	mc, err := NewMonteCarlo(2, 1)
+	require.NoError(t, err)
+
+	_, err = mc.CalculateSamples(-1)
+	require.Error(t, err)
+	require.Equal(t, "number of samples must be non-negative, got -1", err.Error())
+}
+
+func TestMonteCarlo_CalculateSamplesZero(t *testing.T) {
+	mc, err := NewMonteCarlo(2, 1)
+	require.NoError(t, err)
+
+	estimate, err := mc.CalculateSamples(0)
+	require.NoError(t, err)
+	require.Zero(t, estimate.Samples)
+	require.True(t, math.IsInf(estimate.StdErr, 1))
+}
+
+func TestMonteCarlo_CalculateSamplesStopped(t *testing.T) {
+	mc, err := NewMonteCarlo(2, 1)
+	require.NoError(t, err)
+	mc.Stop()
+
+	estimate, err := mc.CalculateSamples(1000)
+	require.ErrorIs(t, err, ErrStopped)
+	require.Less(t, estimate.Samples, 1000)
+}
+
+func TestMonteCarlo_Stop(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		mc, err := NewMonteCarlo(2, 1)
+		require.NoError(t, err)
+
+		done := make(chan MonteCarloEstimate, 1)
+		go func() {
+			done <- mc.Calculate()
+		}()
+
+		mc.Stop()
+		require.NotPanics(t, mc.Stop)
+
+		estimate := <-done
+		require.GreaterOrEqual(t, estimate.Value, 0.0)
+		require.LessOrEqual(t, estimate.Value, 4.0)
+	})
+}
+
+func TestMonteCarlo_CalculateContext(t *testing.T) {
+	mc, err := NewMonteCarlo(4, 1)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	estimate, err := mc.CalculateContext(ctx)
+	require.NoError(t, err)
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+	require.Positive(t, estimate.Samples)
+	require.GreaterOrEqual(t, estimate.Value, 0.0)
+	require.LessOrEqual(t, estimate.Value, 4.0)
+}
+
+func TestMonteCarlo_CalculateContextNoSamples(t *testing.T) {
+	mc, err := NewMonteCarlo(2, 1)
+	require.NoError(t, err)
+	mc.Stop()
+
+	_, err = mc.CalculateContext(t.Context())
+	require.ErrorIs(t, err, ErrNoTerms)
+
+	mc, err = NewMonteCarlo(2, 1)
+	require.NoError(t, err)
+	mc.Stop()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err = mc.CalculateContext(ctx)
+	require.ErrorIs(t, err, ErrNoTerms)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMonteCarloEstimate_ConfidenceInterval(t *testing.T) {
+	estimate := newMonteCarloEstimate(10000, 7854)
+	require.InDelta(t, 3.1416, estimate.Value, 1e-12)
+	require.InDelta(t, 4*math.Sqrt(0.7854*0.2146/10000), estimate.StdErr, 1e-12)
+
+	tests := []struct {
+		level float64
+		z     float64
+	}{
+		{level: 0.6826894921370859, z: 1},
+		{level: 0.95, z: 1.959963984540054},
+		{level: 0.99, z: 2.5758293035489004},
+	}
+
+	for _, tt := range tests {
+		low, high, err := estimate.ConfidenceInterval(tt.level)
+		require.NoError(t, err)
+		require.InDelta(t, estimate.Value-tt.z*estimate.StdErr, low, 1e-9)
+		require.InDelta(t, estimate.Value+tt.z*estimate.StdErr, high, 1e-9)
+	}
+
+	for _, level := range []float64{0, 1, -0.5, 1.5, math.NaN()} {
+		_, _, err := estimate.ConfidenceInterval(level)
+		require.Error(t, err)
+	}
+}
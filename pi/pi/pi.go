@@ -1,10 +1,21 @@
 package pi
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"sync"
+	"math"
+	"sync/atomic"
+	"time"
 )
 
+var ErrStopped = errors.New("calculation stopped before completion")
+
+// ErrNoTerms is returned when a calculation ends without summing a single
+// term, or drawing a single sample, since a series need not give a
+// meaningful estimate for an empty sum; Chudnovsky's would be infinite.
+var ErrNoTerms = errors.New("no terms of the series were summed")
+
 // CalculateLeibnizTerm calculates a single term in the Leibniz series for π/4
 // π/4 = 1 - 1/3 + 1/5 - 1/7 + 1/9 - ...
 // Formula: (-1)^n / (2*n + 1)
@@ -20,68 +31,310 @@ func CalculateLeibnizTerm(n int) float64 {
 }
 
 type Calculator struct {
-	numWorkers int
-	stopChan   chan struct{}
-	results    chan float64
-	wg         *sync.WaitGroup
+	*workerPool[Partial]
+	series Series
+
+	progress         []workerProgress
+	progressInterval time.Duration
+	onProgress       func(Progress)
+	reporterDone     chan struct{}
+}
+
+// Progress is a snapshot of a running calculation.
+type Progress struct {
+	Terms    int
+	Estimate float64
+}
+
+// workerProgress is the running state a worker publishes for the reporter.
+type workerProgress struct {
+	sum   atomic.Uint64
+	terms atomic.Int64
+}
+
+// Workers publish their running sums every progressBatch terms.
+const progressBatch = 1 << 12
+
+// Partial is the share of the series summed by a single worker.
+// Sum is the raw series sum, before it is turned into an estimate of π.
+type Partial struct {
+	Worker int
+	Sum    float64
+	Terms  int
+}
+
+// Estimate is an approximation of π together with a bound on its error.
+type Estimate struct {
+	Value      float64
+	ErrorBound float64
+	Terms      int
 }
 
 func New(numWorkers int) (*Calculator, error) {
-	if numWorkers <= 0 {
-		return nil, fmt.Errorf("number of workers must be positive, got %d", numWorkers)
+	return NewWithSeries(numWorkers, Leibniz{})
+}
+
+// NewWithSeries creates a calculator that sums the given series.
+func NewWithSeries(numWorkers int, series Series) (*Calculator, error) {
+	pool, err := newWorkerPool[Partial](numWorkers)
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		return nil, errors.New("series must not be nil")
 	}
 
 	return &Calculator{
-		numWorkers: numWorkers,
-		stopChan:   make(chan struct{}),
-		results:    make(chan float64, numWorkers),
-		wg:         &sync.WaitGroup{},
+		workerPool: pool,
+		series:     series,
+		progress:   make([]workerProgress, numWorkers),
 	}, nil
 }
 
-func (c *Calculator) worker(id int) {
-	defer c.wg.Done()
+// OnProgress registers fn to be called every interval while a calculation
+// runs and once more with the final totals when it finishes. A non-positive
+// interval reports only the final totals. It must be called before the
+// calculation is started.
+func (c *Calculator) OnProgress(interval time.Duration, fn func(Progress)) {
+	c.progressInterval = interval
+	c.onProgress = fn
+}
 
-	var sum float64
+// worker sums the terms id, id+numWorkers, ... below total, or without
+// end if total is negative. It returns early with a partial sum if the
+// calculator is stopped.
+func (c *Calculator) worker(id, total int) {
+	var sum neumaierSum
+	var terms int
 
-	fmt.Printf("Worker %d has started\n", id)
+	c.logger.Info("worker started", "worker", id)
 
-	for n := id; ; n += c.numWorkers {
-		select {
-		case <-c.stopChan:
-			fmt.Printf("Worker %d has received stop signal\n", id)
-			c.results <- sum
+	for n := id; total < 0 || n < total; n += c.numWorkers {
+		if c.stopping() {
+			c.logger.Info("worker received stop signal", "worker", id, "terms", terms)
+			c.finish(id, sum, terms)
 			return
-		default:
-			term := CalculateLeibnizTerm(n)
-			sum += term
+		}
+
+		sum.Add(c.series.Term(n))
+		terms++
+
+		if terms%progressBatch == 0 {
+			c.publish(id, sum, terms)
 		}
 	}
+
+	c.logger.Info("worker finished", "worker", id, "terms", terms)
+	c.finish(id, sum, terms)
 }
 
-func (c *Calculator) Start() {
-	for i := 0; i < c.numWorkers; i++ {
-		c.wg.Add(1)
-		go c.worker(i)
+func (c *Calculator) publish(id int, sum neumaierSum, terms int) {
+	c.progress[id].sum.Store(math.Float64bits(sum.Value()))
+	c.progress[id].terms.Store(int64(terms))
+}
+
+func (c *Calculator) finish(id int, sum neumaierSum, terms int) {
+	c.publish(id, sum, terms)
+	c.results <- Partial{Worker: id, Sum: sum.Value(), Terms: terms}
+}
+
+// snapshot reduces the published worker state into a Progress report.
+func (c *Calculator) snapshot() Progress {
+	var total neumaierSum
+	var terms int
+
+	for i := range c.progress {
+		total.Add(math.Float64frombits(c.progress[i].sum.Load()))
+		terms += int(c.progress[i].terms.Load())
+	}
+
+	return Progress{Terms: terms, Estimate: c.series.Estimate(total.Value())}
+}
+
+// report calls the progress callback every interval until finished is
+// closed.
+func (c *Calculator) report(finished <-chan struct{}) {
+	defer close(c.reporterDone)
+
+	ticker := time.NewTicker(c.progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.onProgress(c.snapshot())
+		case <-finished:
+			return
+		}
 	}
+}
 
-	go func() {
-		c.wg.Wait()
-		close(c.results)
-	}()
+func (c *Calculator) Start() {
+	c.start(-1)
 }
 
-func (c *Calculator) Stop() {
-	close(c.stopChan)
+// start sums the first total terms, or runs until stopped if total is
+// negative.
+func (c *Calculator) start(total int) {
+	finished := c.workerPool.start(func(id int) {
+		c.worker(id, total)
+	})
+
+	if c.onProgress != nil && c.progressInterval > 0 {
+		c.reporterDone = make(chan struct{})
+		go c.report(finished)
+	}
 }
 
 func (c *Calculator) Calculate() float64 {
 	c.Start()
+	value, _ := c.collect()
+	return value
+}
+
+// CalculateContext runs the workers until ctx is done or Stop is called
+// and returns the estimate accumulated so far, or ErrNoTerms if they were
+// stopped before summing anything.
+func (c *Calculator) CalculateContext(ctx context.Context) (float64, error) {
+	defer context.AfterFunc(ctx, c.Stop)()
+
+	c.Start()
+	return c.result()
+}
+
+// CalculateTerms sums exactly the first terms terms of the series, split
+// across the workers. Calling Stop cuts the computation short, in which
+// case the estimate from the terms summed so far comes with ErrStopped.
+// It returns ErrNoTerms if no term was summed, because terms is zero or
+// Stop came first.
+func (c *Calculator) CalculateTerms(terms int) (float64, error) {
+	if terms < 0 {
+		return 0, fmt.Errorf("number of terms must be non-negative, got %d", terms)
+	}
+
+	c.start(terms)
+
+	value, err := c.result()
+	if err == nil && c.stopped.Load() {
+		err = ErrStopped
+	}
+	return value, err
+}
+
+// TermsForPrecision returns the smallest number of terms of s whose
+// estimate is within precision of π according to s.ErrorBound. For the
+// alternating series in this package the truncation error is bounded by
+// the first omitted term, e.g. 4 * |t_n| for Leibniz.
+func TermsForPrecision(s Series, precision float64) (int, error) {
+	if math.IsNaN(precision) || precision <= 0 {
+		return 0, fmt.Errorf("precision must be positive, got %g", precision)
+	}
+
+	if s.ErrorBound(0) <= precision {
+		return 0, nil
+	}
+
+	lo, hi := 0, 1
+	for s.ErrorBound(hi) > precision {
+		if hi > math.MaxInt/4 {
+			return 0, fmt.Errorf("precision %g requires too many terms", precision)
+		}
+		lo, hi = hi, hi*2
+	}
+
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if s.ErrorBound(mid) <= precision {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return hi, nil
+}
+
+// CalculatePrecision sums as many terms as needed to approximate π within
+// precision. The bound covers the truncation error of the series only.
+func (c *Calculator) CalculatePrecision(precision float64) (Estimate, error) {
+	terms, err := TermsForPrecision(c.series, precision)
+	if err != nil {
+		return Estimate{}, err
+	}
+	if terms == 0 {
+		return Estimate{Value: c.series.Estimate(0), ErrorBound: c.series.ErrorBound(0)}, nil
+	}
 
-	var totalSum float64
-	for result := range c.results {
-		totalSum += result
+	value, err := c.CalculateTerms(terms)
+	if c.stopped.Load() {
+		return Estimate{}, ErrStopped
 	}
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	return Estimate{
+		Value:      value,
+		ErrorBound: c.series.ErrorBound(terms),
+		Terms:      terms,
+	}, nil
+}
+
+// Partials returns the per-worker sums of the last finished calculation,
+// ordered by worker id.
+func (c *Calculator) Partials() []Partial {
+	return c.lastResults()
+}
+
+// result collects the estimate, failing if it rests on no terms at all.
+func (c *Calculator) result() (float64, error) {
+	value, terms := c.collect()
+	if terms == 0 {
+		return 0, ErrNoTerms
+	}
+	return value, nil
+}
+
+// collect gathers the worker results and reduces them in worker order, so
+// the estimate does not depend on which worker finished first. It also
+// returns the number of terms summed.
+func (c *Calculator) collect() (float64, int) {
+	partials := c.workerPool.collect(func(p Partial) int { return p.Worker })
+
+	if c.reporterDone != nil {
+		<-c.reporterDone
+	}
+	if c.onProgress != nil {
+		c.onProgress(c.snapshot())
+	}
+
+	var total neumaierSum
+	var terms int
+	for _, p := range partials {
+		total.Add(p.Sum)
+		terms += p.Terms
+	}
+
+	return c.series.Estimate(total.Value()), terms
+}
+
+// neumaierSum accumulates floats with Neumaier's variant of Kahan
+// summation, carrying the lost low-order bits in a separate compensation.
+type neumaierSum struct {
+	sum          float64
+	compensation float64
+}
+
+func (s *neumaierSum) Add(x float64) {
+	t := s.sum + x
+	if math.Abs(s.sum) >= math.Abs(x) {
+		s.compensation += (s.sum - t) + x
+	} else {
+		s.compensation += (x - t) + s.sum
+	}
+	s.sum = t
+}
 
-	return totalSum * 4
+func (s *neumaierSum) Value() float64 {
+	return s.sum + s.compensation
 }
@@ -1,10 +1,17 @@
 package pi
 
 import (
+	"bytes"
+	"context"
 	"github.com/stretchr/testify/require"
+	"log/slog"
 	"math"
+	"math/big"
+	"strings"
+	"sync"
 	"testing"
 	"testing/synctest"
+	"time"
 )
 
 func TestNew(t *testing.T) {
@@ -47,7 +54,6 @@ func TestNew(t *testing.T) {
 				require.Equal(t, tt.numWorkers, calc.numWorkers)
 				require.NotNil(t, calc.stopChan)
 				require.NotNil(t, calc.results)
-				require.NotNil(t, calc.wg)
 			}
 		})
 	}
@@ -154,3 +160,401 @@ func TestCalculator_Stop(t *testing.T) {
 		require.True(t, result >= 0, "Result should be non-negative")
 	})
 }
+
+func TestCalculator_StopTwice(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		calc, err := New(2)
+		require.NoError(t, err)
+
+		done := make(chan float64, 1)
+		go func() {
+			done <- calc.Calculate()
+		}()
+
+		calc.Stop()
+		require.NotPanics(t, calc.Stop)
+
+		<-done
+	})
+}
+
+func TestCalculator_CalculateContext(t *testing.T) {
+	tests := []struct {
+		name       string
+		numWorkers int
+	}{
+		{name: "single worker", numWorkers: 1},
+		{name: "multiple workers", numWorkers: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc, err := New(tt.numWorkers)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+			defer cancel()
+
+			result, err := calc.CalculateContext(ctx)
+			require.NoError(t, err)
+			require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+			require.False(t, math.IsNaN(result) || math.IsInf(result, 0), "Result %f should be finite", result)
+		})
+	}
+}
+
+func TestCalculator_CalculateContextCanceled(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		calc, err := New(3)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		// The workers may or may not see the stop before their first term.
+		result, err := calc.CalculateContext(ctx)
+		if err != nil {
+			require.ErrorIs(t, err, ErrNoTerms)
+		} else {
+			require.False(t, math.IsNaN(result) || math.IsInf(result, 0), "Result %f should be finite", result)
+		}
+		require.NotPanics(t, calc.Stop)
+	})
+}
+
+func TestCalculator_CalculateTerms(t *testing.T) {
+	tests := []struct {
+		name       string
+		numWorkers int
+		terms      int
+	}{
+		{name: "fewer terms than workers", numWorkers: 8, terms: 3},
+		{name: "single worker", numWorkers: 1, terms: 1000},
+		{name: "uneven split", numWorkers: 3, terms: 1000},
+		{name: "many workers", numWorkers: 16, terms: 100000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc, err := New(tt.numWorkers)
+			require.NoError(t, err)
+
+			var expected float64
+			for n := 0; n < tt.terms; n++ {
+				expected += CalculateLeibnizTerm(n)
+			}
+
+			result, err := calc.CalculateTerms(tt.terms)
+			require.NoError(t, err)
+			require.InDelta(t, expected*4, result, 1e-9)
+		})
+	}
+}
+
+func TestCalculator_CalculateTermsNone(t *testing.T) {
+	calc, err := New(2)
+	require.NoError(t, err)
+
+	_, err = calc.CalculateTerms(0)
+	require.ErrorIs(t, err, ErrNoTerms)
+
+	// Stopped before the first term, Chudnovsky's estimate would be +Inf.
+	calc, err = NewWithSeries(2, Chudnovsky{})
+	require.NoError(t, err)
+	calc.Stop()
+
+	_, err = calc.CalculateTerms(10)
+	require.ErrorIs(t, err, ErrNoTerms)
+
+	calc, err = NewWithSeries(2, Chudnovsky{})
+	require.NoError(t, err)
+	calc.Stop()
+
+	_, err = calc.CalculateContext(t.Context())
+	require.ErrorIs(t, err, ErrNoTerms)
+}
+
+// stoppingSeries is the Leibniz series, except that it calls stop once
+// term at has been summed.
+type stoppingSeries struct {
+	Leibniz
+	at   int
+	stop func()
+}
+
+func (s stoppingSeries) Term(n int) float64 {
+	if n == s.at {
+		s.stop()
+	}
+	return s.Leibniz.Term(n)
+}
+
+func TestCalculator_CalculateTermsStopped(t *testing.T) {
+	series := &stoppingSeries{at: 4}
+	calc, err := NewWithSeries(1, series)
+	require.NoError(t, err)
+	series.stop = calc.Stop
+
+	result, err := calc.CalculateTerms(1000)
+	require.ErrorIs(t, err, ErrStopped)
+	require.InDelta(t, 4*(1-1.0/3+1.0/5-1.0/7+1.0/9), result, 1e-12)
+	require.Equal(t, 5, calc.Partials()[0].Terms)
+}
+
+func TestCalculator_CalculateTermsNegative(t *testing.T) {
+	calc, err := New(2)
+	require.NoError(t, err)
+
+	_, err = calc.CalculateTerms(-1)
+	require.Error(t, err)
+	require.Equal(t, "number of terms must be non-negative, got -1", err.Error())
+}
+
+func TestTermsForPrecision(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision float64
+		expected  int
+		wantErr   bool
+	}{
+		{name: "coarse precision", precision: 4, expected: 0},
+		{name: "precision of one", precision: 1, expected: 2},
+		{name: "1e-2", precision: 1e-2, expected: 200},
+		{name: "1e-4", precision: 1e-4, expected: 20000},
+		{name: "zero precision", precision: 0, wantErr: true},
+		{name: "negative precision", precision: -1e-3, wantErr: true},
+		{name: "NaN precision", precision: math.NaN(), wantErr: true},
+		{name: "unreachable precision", precision: 1e-300, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms, err := TermsForPrecision(Leibniz{}, tt.precision)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, terms)
+			require.LessOrEqual(t, 4*math.Abs(CalculateLeibnizTerm(terms)), tt.precision)
+			if terms > 0 {
+				require.Greater(t, 4*math.Abs(CalculateLeibnizTerm(terms-1)), tt.precision)
+			}
+		})
+	}
+}
+
+func TestCalculator_CalculatePrecision(t *testing.T) {
+	tests := []struct {
+		name       string
+		numWorkers int
+		precision  float64
+	}{
+		{name: "single worker", numWorkers: 1, precision: 1e-3},
+		{name: "multiple workers", numWorkers: 4, precision: 1e-4},
+		{name: "many workers", numWorkers: 16, precision: 1e-6},
+		{name: "no terms needed", numWorkers: 2, precision: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc, err := New(tt.numWorkers)
+			require.NoError(t, err)
+
+			estimate, err := calc.CalculatePrecision(tt.precision)
+			require.NoError(t, err)
+
+			expectedTerms, err := TermsForPrecision(Leibniz{}, tt.precision)
+			require.NoError(t, err)
+			require.Equal(t, expectedTerms, estimate.Terms)
+			require.LessOrEqual(t, estimate.ErrorBound, tt.precision)
+			require.LessOrEqual(t, math.Abs(estimate.Value-math.Pi), estimate.ErrorBound)
+		})
+	}
+}
+
+func TestCalculator_CalculatePrecisionInvalid(t *testing.T) {
+	calc, err := New(2)
+	require.NoError(t, err)
+
+	_, err = calc.CalculatePrecision(0)
+	require.Error(t, err)
+	require.Equal(t, "precision must be positive, got 0", err.Error())
+}
+
+func TestCalculator_CalculatePrecisionStopped(t *testing.T) {
+	calc, err := New(2)
+	require.NoError(t, err)
+
+	calc.Stop()
+
+	_, err = calc.CalculatePrecision(1e-3)
+	require.ErrorIs(t, err, ErrStopped)
+}
+
+func TestNeumaierSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		expected float64
+	}{
+		{name: "empty", values: nil, expected: 0},
+		{name: "cancellation", values: []float64{1, 1e100, 1, -1e100}, expected: 2},
+		{name: "small after large", values: []float64{1e16, 1, 1, 1, 1}, expected: 1e16 + 4},
+		{name: "tenths", values: []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sum neumaierSum
+			for _, v := range tt.values {
+				sum.Add(v)
+			}
+			require.Equal(t, tt.expected, sum.Value())
+		})
+	}
+}
+
+func TestCalculator_CalculateTermsDeterministic(t *testing.T) {
+	const terms = 200000
+
+	var expected float64
+	for i := 0; i < 5; i++ {
+		calc, err := New(8)
+		require.NoError(t, err)
+
+		result, err := calc.CalculateTerms(terms)
+		require.NoError(t, err)
+
+		if i == 0 {
+			expected = result
+			continue
+		}
+		require.Equal(t, math.Float64bits(expected), math.Float64bits(result), "run %d", i)
+	}
+}
+
+func TestCalculator_CalculateTermsAccuracy(t *testing.T) {
+	const terms = 1000000
+
+	exact := new(big.Float).SetPrec(200)
+	for n := 0; n < terms; n++ {
+		exact.Add(exact, new(big.Float).SetPrec(200).SetFloat64(CalculateLeibnizTerm(n)))
+	}
+	exact.Mul(exact, big.NewFloat(4))
+	expected, _ := exact.Float64()
+
+	for _, numWorkers := range []int{1, 3, 8} {
+		calc, err := New(numWorkers)
+		require.NoError(t, err)
+
+		result, err := calc.CalculateTerms(terms)
+		require.NoError(t, err)
+		require.InDelta(t, expected, result, 1e-15, "numWorkers=%d", numWorkers)
+	}
+}
+
+func TestCalculator_Partials(t *testing.T) {
+	calc, err := New(3)
+	require.NoError(t, err)
+	require.Empty(t, calc.Partials())
+
+	result, err := calc.CalculateTerms(10)
+	require.NoError(t, err)
+
+	partials := calc.Partials()
+	require.Len(t, partials, 3)
+
+	expectedTerms := []int{4, 3, 3}
+	var total float64
+	for i, p := range partials {
+		require.Equal(t, i, p.Worker)
+		require.Equal(t, expectedTerms[i], p.Terms)
+
+		var expected float64
+		for n := i; n < 10; n += 3 {
+			expected += CalculateLeibnizTerm(n)
+		}
+		require.InDelta(t, expected, p.Sum, 1e-15)
+		total += p.Sum
+	}
+
+	require.InDelta(t, result, total*4, 1e-15)
+
+	partials[0].Sum = 42
+	require.NotEqual(t, 42.0, calc.Partials()[0].Sum)
+}
+
+func TestCalculator_SetLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	calc, err := New(2)
+	require.NoError(t, err)
+	calc.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	_, err = calc.CalculateTerms(10)
+	require.NoError(t, err)
+
+	logs := buf.String()
+	require.Equal(t, 2, strings.Count(logs, "msg=\"worker started\""))
+	require.Equal(t, 2, strings.Count(logs, "msg=\"worker finished\""))
+	require.Contains(t, logs, "worker=0 terms=5")
+	require.Contains(t, logs, "worker=1 terms=5")
+}
+
+func TestCalculator_SetLoggerNil(t *testing.T) {
+	calc, err := New(2)
+	require.NoError(t, err)
+	calc.SetLogger(nil)
+
+	_, err = calc.CalculateTerms(10)
+	require.NoError(t, err)
+}
+
+func TestCalculator_OnProgress(t *testing.T) {
+	const terms = 20000000
+
+	calc, err := New(4)
+	require.NoError(t, err)
+
+	var (
+		mu      sync.Mutex
+		reports []Progress
+	)
+	calc.OnProgress(time.Millisecond, func(p Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, p)
+	})
+
+	result, err := calc.CalculateTerms(terms)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.NotEmpty(t, reports)
+	for i := 1; i < len(reports); i++ {
+		require.GreaterOrEqual(t, reports[i].Terms, reports[i-1].Terms)
+	}
+
+	last := reports[len(reports)-1]
+	require.Equal(t, terms, last.Terms)
+	require.InDelta(t, result, last.Estimate, 1e-15)
+}
+
+func TestCalculator_OnProgressFinalOnly(t *testing.T) {
+	calc, err := New(3)
+	require.NoError(t, err)
+
+	var reports []Progress
+	calc.OnProgress(0, func(p Progress) {
+		reports = append(reports, p)
+	})
+
+	result, err := calc.CalculateTerms(1000)
+	require.NoError(t, err)
+	require.Equal(t, []Progress{{Terms: 1000, Estimate: result}}, reports)
+}
@@ -0,0 +1,167 @@
+package pi
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Series is an infinite series whose partial sums converge to π.
+// Term returns the n-th term, Estimate turns a partial sum into an
+// approximation of π and ErrorBound bounds the truncation error of the
+// estimate built from the first terms terms.
+type Series interface {
+	Name() string
+	Term(n int) float64
+	Estimate(sum float64) float64
+	ErrorBound(terms int) float64
+}
+
+// Leibniz sums π/4 = 1 - 1/3 + 1/5 - 1/7 + ...
+type Leibniz struct{}
+
+func (Leibniz) Name() string {
+	return "leibniz"
+}
+
+func (Leibniz) Term(n int) float64 {
+	return CalculateLeibnizTerm(n)
+}
+
+func (Leibniz) Estimate(sum float64) float64 {
+	return sum * 4
+}
+
+func (Leibniz) ErrorBound(terms int) float64 {
+	return 4 * math.Abs(CalculateLeibnizTerm(terms))
+}
+
+// Nilakantha sums π = 3 + 4/(2*3*4) - 4/(4*5*6) + 4/(6*7*8) - ...
+type Nilakantha struct{}
+
+func (Nilakantha) Name() string {
+	return "nilakantha"
+}
+
+func (Nilakantha) Term(n int) float64 {
+	if n == 0 {
+		return 3
+	}
+
+	k := 2 * float64(n)
+	term := 4 / (k * (k + 1) * (k + 2))
+
+	if n%2 == 0 {
+		term = -term
+	}
+
+	return term
+}
+
+func (Nilakantha) Estimate(sum float64) float64 {
+	return sum
+}
+
+func (s Nilakantha) ErrorBound(terms int) float64 {
+	if terms == 0 {
+		return 4
+	}
+	return math.Abs(s.Term(terms))
+}
+
+// Machin sums π = 16*arctan(1/5) - 4*arctan(1/239), expanding both
+// arctangents as Taylor series.
+type Machin struct{}
+
+func (Machin) Name() string {
+	return "machin"
+}
+
+func (Machin) Term(n int) float64 {
+	k := float64(2*n + 1)
+	term := (16/math.Pow(5, k) - 4/math.Pow(239, k)) / k
+
+	if n%2 == 1 {
+		term = -term
+	}
+
+	return term
+}
+
+func (Machin) Estimate(sum float64) float64 {
+	return sum
+}
+
+// ErrorBound adds the alternating-series bounds of both arctangents.
+func (Machin) ErrorBound(terms int) float64 {
+	k := float64(2*terms + 1)
+	return (16/math.Pow(5, k) + 4/math.Pow(239, k)) / k
+}
+
+// Chudnovsky sums 1/π = 12 * Σ (-1)^k (6k)! (13591409 + 545140134k) /
+// ((3k)! (k!)^3 640320^(3k+3/2)). Each term adds about 14 digits, so in
+// float64 the first two terms already reach full precision.
+type Chudnovsky struct{}
+
+func (Chudnovsky) Name() string {
+	return "chudnovsky"
+}
+
+func (Chudnovsky) Term(n int) float64 {
+	k := float64(n)
+
+	lg6k, _ := math.Lgamma(6*k + 1)
+	lg3k, _ := math.Lgamma(3*k + 1)
+	lgk, _ := math.Lgamma(k + 1)
+
+	logTerm := lg6k - lg3k - 3*lgk +
+		math.Log(13591409+545140134*k) -
+		(3*k+1.5)*math.Log(640320)
+	term := math.Exp(logTerm)
+
+	if n%2 == 1 {
+		term = -term
+	}
+
+	return term
+}
+
+func (Chudnovsky) Estimate(sum float64) float64 {
+	return 1 / (12 * sum)
+}
+
+// ErrorBound propagates the alternating-series bound |t_n| on the sum S
+// through π = 1/(12S). Once the first term is included the partial sum
+// stays above S/2, which gives |Δπ| <= 24π²|t_n|.
+func (s Chudnovsky) ErrorBound(terms int) float64 {
+	if terms == 0 {
+		return math.Inf(1)
+	}
+	return 24 * math.Pi * math.Pi * math.Abs(s.Term(terms))
+}
+
+var seriesByName = map[string]Series{
+	Leibniz{}.Name():    Leibniz{},
+	Nilakantha{}.Name(): Nilakantha{},
+	Machin{}.Name():     Machin{},
+	Chudnovsky{}.Name(): Chudnovsky{},
+}
+
+// SeriesByName returns the series registered under name.
+func SeriesByName(name string) (Series, error) {
+	s, ok := seriesByName[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown series %q, expected one of %v", name, SeriesNames())
+	}
+	return s, nil
+}
+
+// SeriesNames returns the names accepted by SeriesByName in sorted order.
+func SeriesNames() []string {
+	names := make([]string, 0, len(seriesByName))
+	for name := range seriesByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,155 @@
+package pi
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var allSeries = []Series{Leibniz{}, Nilakantha{}, Machin{}, Chudnovsky{}}
+
+func partialEstimate(s Series, terms int) float64 {
+	var sum float64
+	for n := 0; n < terms; n++ {
+		sum += s.Term(n)
+	}
+	return s.Estimate(sum)
+}
+
+func TestSeriesTerms(t *testing.T) {
+	tests := []struct {
+		name     string
+		series   Series
+		n        int
+		expected float64
+	}{
+		{name: "leibniz first term", series: Leibniz{}, n: 0, expected: 1},
+		{name: "leibniz second term", series: Leibniz{}, n: 1, expected: -1.0 / 3.0},
+		{name: "nilakantha first term", series: Nilakantha{}, n: 0, expected: 3},
+		{name: "nilakantha second term", series: Nilakantha{}, n: 1, expected: 4.0 / 24.0},
+		{name: "nilakantha third term", series: Nilakantha{}, n: 2, expected: -4.0 / 120.0},
+		{name: "machin first term", series: Machin{}, n: 0, expected: 16.0/5.0 - 4.0/239.0},
+		{name: "machin second term", series: Machin{}, n: 1, expected: -(16.0/125.0 - 4.0/(239.0*239.0*239.0)) / 3},
+		{name: "chudnovsky first term", series: Chudnovsky{}, n: 0, expected: 13591409 / math.Pow(640320, 1.5)},
+		{
+			name:     "chudnovsky second term",
+			series:   Chudnovsky{},
+			n:        1,
+			expected: -720 * (13591409 + 545140134) / (6 * math.Pow(640320, 4.5)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InEpsilon(t, tt.expected, tt.series.Term(tt.n), 1e-12)
+		})
+	}
+}
+
+func TestSeriesErrorBound(t *testing.T) {
+	for _, s := range allSeries {
+		t.Run(s.Name(), func(t *testing.T) {
+			for terms := 1; terms <= 8; terms++ {
+				actual := math.Abs(partialEstimate(s, terms) - math.Pi)
+				bound := s.ErrorBound(terms)
+
+				// Below float64 resolution the bound is meaningless.
+				if bound < 1e-14 {
+					break
+				}
+
+				require.LessOrEqual(t, actual, bound, "terms=%d", terms)
+				require.LessOrEqual(t, bound, s.ErrorBound(terms-1), "bound must not grow, terms=%d", terms)
+			}
+		})
+	}
+}
+
+func TestSeriesConvergenceRates(t *testing.T) {
+	const terms = 3
+
+	errs := make([]float64, len(allSeries))
+	for i, s := range allSeries {
+		errs[i] = math.Abs(partialEstimate(s, terms) - math.Pi)
+	}
+
+	for i := 1; i < len(allSeries); i++ {
+		require.Less(t, errs[i], errs[i-1],
+			"%s should converge faster than %s", allSeries[i].Name(), allSeries[i-1].Name())
+	}
+
+	required := make([]int, len(allSeries))
+	for i, s := range allSeries {
+		n, err := TermsForPrecision(s, 1e-9)
+		require.NoError(t, err)
+		required[i] = n
+	}
+
+	require.Equal(t, []int{2000000000, 794, 7, 1}, required)
+}
+
+func TestSeriesEstimatesPi(t *testing.T) {
+	tests := []struct {
+		series Series
+		terms  int
+		delta  float64
+	}{
+		{series: Leibniz{}, terms: 100000, delta: 1e-4},
+		{series: Nilakantha{}, terms: 1000, delta: 1e-9},
+		{series: Machin{}, terms: 12, delta: 1e-14},
+		{series: Chudnovsky{}, terms: 2, delta: 1e-14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.series.Name(), func(t *testing.T) {
+			calc, err := NewWithSeries(4, tt.series)
+			require.NoError(t, err)
+
+			result, err := calc.CalculateTerms(tt.terms)
+			require.NoError(t, err)
+			require.InDelta(t, math.Pi, result, tt.delta)
+		})
+	}
+}
+
+func TestCalculator_CalculatePrecisionSeries(t *testing.T) {
+	for _, s := range allSeries {
+		t.Run(s.Name(), func(t *testing.T) {
+			calc, err := NewWithSeries(3, s)
+			require.NoError(t, err)
+
+			estimate, err := calc.CalculatePrecision(1e-6)
+			require.NoError(t, err)
+			require.LessOrEqual(t, estimate.ErrorBound, 1e-6)
+			require.LessOrEqual(t, math.Abs(estimate.Value-math.Pi), estimate.ErrorBound)
+		})
+	}
+}
+
+func TestNewWithSeries(t *testing.T) {
+	calc, err := NewWithSeries(2, Machin{})
+	require.NoError(t, err)
+	require.Equal(t, Machin{}, calc.series)
+
+	calc, err = NewWithSeries(2, nil)
+	require.Error(t, err)
+	require.Nil(t, calc)
+	require.Equal(t, "series must not be nil", err.Error())
+
+	calc, err = NewWithSeries(0, Machin{})
+	require.Error(t, err)
+	require.Nil(t, calc)
+}
+
+func TestSeriesByName(t *testing.T) {
+	for _, s := range allSeries {
+		got, err := SeriesByName(s.Name())
+		require.NoError(t, err)
+		require.Equal(t, s, got)
+	}
+
+	_, err := SeriesByName("ramanujan")
+	require.Error(t, err)
+	require.Equal(t, `unknown series "ramanujan", expected one of [chudnovsky leibniz machin nilakantha]`, err.Error())
+}
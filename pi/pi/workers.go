@@ -0,0 +1,104 @@
+package pi
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// workerPool runs the goroutines of a calculation and gathers one result
+// of type R from each. It holds the lifecycle shared by Calculator and
+// MonteCarlo: starting the workers, stopping them early and collecting
+// their results in a fixed order.
+type workerPool[R any] struct {
+	numWorkers int
+	stopChan   chan struct{}
+	results    chan R
+	last       []R
+	wg         sync.WaitGroup
+	stopOnce   sync.Once
+	stopped    atomic.Bool
+	logger     *slog.Logger
+}
+
+func newWorkerPool[R any](numWorkers int) (*workerPool[R], error) {
+	if numWorkers <= 0 {
+		return nil, fmt.Errorf("number of workers must be positive, got %d", numWorkers)
+	}
+
+	return &workerPool[R]{
+		numWorkers: numWorkers,
+		stopChan:   make(chan struct{}),
+		results:    make(chan R, numWorkers),
+		logger:     slog.New(slog.DiscardHandler),
+	}, nil
+}
+
+// SetLogger sets the logger the workers report to. By default nothing is
+// logged.
+func (p *workerPool[R]) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	p.logger = logger
+}
+
+// Stop signals the workers to finish. It is safe to call more than once.
+func (p *workerPool[R]) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopChan)
+	})
+}
+
+// stopping reports whether Stop has been called. A worker that sees it
+// with work left marks the calculation as cut short.
+func (p *workerPool[R]) stopping() bool {
+	select {
+	case <-p.stopChan:
+		p.stopped.Store(true)
+		return true
+	default:
+		return false
+	}
+}
+
+// start runs worker(id) for each worker id. The returned channel is
+// closed once all of them have returned, just before the results are.
+func (p *workerPool[R]) start(worker func(id int)) <-chan struct{} {
+	finished := make(chan struct{})
+
+	for i := 0; i < p.numWorkers; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			worker(i)
+		}()
+	}
+
+	go func() {
+		p.wg.Wait()
+		close(finished)
+		close(p.results)
+	}()
+
+	return finished
+}
+
+// collect gathers the worker results ordered by the id that idOf reports,
+// so that reducing them does not depend on which worker finished first.
+func (p *workerPool[R]) collect(idOf func(R) int) []R {
+	results := make([]R, p.numWorkers)
+	for result := range p.results {
+		results[idOf(result)] = result
+	}
+	p.last = results
+	return results
+}
+
+// lastResults returns a copy of the results of the last finished
+// calculation.
+func (p *workerPool[R]) lastResults() []R {
+	return slices.Clone(p.last)
+}
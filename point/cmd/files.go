@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"point/point"
+)
+
+// loadPoints reads the points of the --geojson and --gpx files, in that
+// order. A file name of "-" reads standard input.
+func loadPoints(geojsonFile, gpxFile string) ([]point.Point, error) {
+	var points []point.Point
+
+	if geojsonFile != "" {
+		err := withInput(geojsonFile, func(r io.Reader) error {
+			_, pts, err := point.ReadGeoJSON(r)
+			points = append(points, pts...)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading --geojson %s: %w", geojsonFile, err)
+		}
+	}
+
+	if gpxFile != "" {
+		err := withInput(gpxFile, func(r io.Reader) error {
+			pts, err := point.ReadGPX(r)
+			points = append(points, pts...)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading --gpx %s: %w", gpxFile, err)
+		}
+	}
+
+	return points, nil
+}
+
+func withInput(name string, read func(r io.Reader) error) error {
+	if name == "-" {
+		return read(os.Stdin)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return read(f)
+}
+
+// writePoints encodes points as GeoJSON or GPX. GeoJSON output is a
+// LineString for routes, a Point for a single point and a MultiPoint
+// otherwise.
+func writePoints(w io.Writer, format string, points []point.Point, route bool) error {
+	switch format {
+	case "geojson":
+		typ := point.GeoJSONMultiPoint
+		switch {
+		case route:
+			typ = point.GeoJSONLineString
+		case len(points) == 1:
+			typ = point.GeoJSONPoint
+		}
+		return point.WriteGeoJSON(w, typ, points)
+	case "gpx":
+		return point.WriteGPX(w, points)
+	default:
+		return fmt.Errorf("unknown output format %q, expected geojson or gpx", format)
+	}
+}
+
+func printRouteStats(points []point.Point) error {
+	stats, err := point.Stats(points)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nRoute of %d points:\n", len(points))
+	for i, d := range stats.SegmentsKm {
+		fmt.Printf("  Leg %d: %s to %s: %.2f km\n", i+1, points[i], points[i+1], d)
+	}
+	fmt.Printf("  Total length: %.2f km\n", stats.LengthKm)
+	fmt.Printf("  Bounding box: %s\n", stats.BBox)
+	return nil
+}
@@ -10,69 +10,140 @@ import (
 	"github.com/spf13/pflag"
 )
 
-// go run main.go --point=2,0 --point=0,0 --distance
+// go run . --point=2,0 --point=0,0 --distance
 
-// go run main.go --point=55.7558,37.6176 --point=59.9311,30.3609 --distance
+// go run . --point=55.7558,37.6176 --point=59.9311,30.3609 --distance
 
-// go run main.go \
+// go run . \
 //  --point=55.7558,37.6176 \
 //  --point=59.9311,30.3609 \
 //  --point=56.8431,60.6454 \
 //  --distance
 
-// go run main.go \
+// go run . \
 //   --point=55.7558,37.6176 \
 //   --point=55.9,37.8 \
 //   --point=50.0,40.0 \
 //   --center=55.7558,37.6176 \
 //   --radius=100
 
-// go run main.go --point=90,180 --point=-90,-180 --distance
+// go run . --point=90,180 --point=-90,-180 --distance
+
+// go run . --point=55.7558,37.6176 --point=59.9311,30.3609 --distance --ellipsoid
+
+// go run . --gpx=ride.gpx --route
+
+// go run . --geojson=route.json --point=56.8431,60.6454 --route --output=geojson
+
+// go run . --point=55.7558,37.6176 --point=59.9311,30.3609 --output=gpx > route.gpx
+
+// go run . --geojson=stops.json --matrix=csv --workers=8 > matrix.csv
+
+// go run . --point=55.7558,37.6176 --point=59.9311,30.3609 --point=56.8431,60.6454 --matrix=json --ellipsoid
 
 // Errors:
-// go run main.go --point=55.7558 37.6176 --distance
-// go run main.go --distance
+// go run . --point=55.7558 37.6176 --distance
+// go run . --distance
 
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			return
+		}
+	}
+
 	var pointStrings []string
 	var distance bool
+	var ellipsoid bool
 	var radius float64
 	var centerPoint string
+	var geojsonFile, gpxFile, output, matrix string
+	var route bool
+	var workers int
 
 	pflag.StringArrayVar(&pointStrings, "point", []string{},
-		"Point coordinates in format lat,lng (can be specified multiple times)")
+		"Point as lat,lng, DMS, DDM, geo URI or WKT (can be specified multiple times)")
 	pflag.BoolVar(&distance, "distance", false,
 		"Calculate distance between points")
+	pflag.BoolVar(&ellipsoid, "ellipsoid", false,
+		"Measure distances on the WGS-84 ellipsoid instead of a sphere")
 	pflag.Float64Var(&radius, "radius", 0,
 		"Radius in km for radius check")
 	pflag.StringVar(&centerPoint, "center", "",
 		"Center point for radius check in format lat,lng")
+	pflag.StringVar(&geojsonFile, "geojson", "",
+		"Read points from a GeoJSON Point, MultiPoint or LineString file ('-' for stdin)")
+	pflag.StringVar(&gpxFile, "gpx", "",
+		"Read track points from a GPX file ('-' for stdin)")
+	pflag.BoolVar(&route, "route", false,
+		"Treat points as an ordered route and print its length, legs and bounding box")
+	pflag.StringVar(&output, "output", "",
+		"Write the points to stdout as geojson or gpx instead of a report")
+	pflag.StringVar(&matrix, "matrix", "",
+		"Write the distance matrix of all points to stdout as csv or json")
+	pflag.IntVar(&workers, "workers", 0,
+		"Number of workers computing the distance matrix (0 uses all CPUs)")
 
 	pflag.Usage = func() {
 		programName := "point"
 		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n", programName)
+		fmt.Fprintf(os.Stderr, "       %s bearing|destination|midpoint|intermediate|format|geohash [options]\n", programName)
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  %s --point=55.7558,37.6176 --point=59.9311,30.3609 --distance\n", programName)
 		fmt.Fprintf(os.Stderr, "  %s --point=55.7558,37.6176 --center=55.7558,37.6176 --radius=10\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s --gpx=ride.gpx --route --output=geojson\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s --geojson=stops.json --matrix=csv --ellipsoid\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s bearing --from=55.7558,37.6176 --to=59.9311,30.3609\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s destination --from=55.7558,37.6176 --bearing=320 --distance=633\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s geohash --point=55.7558,37.6176 --precision=7 --neighbors\n", programName)
 		fmt.Fprintf(os.Stderr, "\nOptions:\n")
 		pflag.PrintDefaults()
 	}
 
 	pflag.Parse()
 
-	if len(pointStrings) == 0 {
+	points, err := loadPoints(geojsonFile, gpxFile)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	parsed, err := point.ParsePoints(pointStrings)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	points = append(points, parsed...)
+
+	if len(points) == 0 {
 		fmt.Fprintf(os.Stderr, "Error: at least one point must be specified\n")
 		pflag.Usage()
 		os.Exit(1)
 	}
 
-	points, err := point.ParsePoints(pointStrings)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
+	if matrix != "" {
+		if err := writeMatrix(os.Stdout, matrix, points, workers, ellipsoid); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		return
+	}
+
+	if output != "" {
+		if err := writePoints(os.Stdout, output, points, route); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		return
+	}
+
+	if route {
+		if err := printRouteStats(points); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
 	}
 
 	if distance {
-		printDistances(points)
+		printDistances(points, ellipsoid)
 	}
 
 	if radius > 0 && centerPoint != "" {
@@ -82,7 +153,7 @@ func main() {
 	}
 }
 
-func printDistances(points []point.Point) {
+func printDistances(points []point.Point, ellipsoid bool) {
 	fmt.Printf("\nDistances between points:\n")
 
 	if len(points) < 2 {
@@ -92,6 +163,13 @@ func printDistances(points []point.Point) {
 
 	for i := 0; i < len(points); i++ {
 		for j := i + 1; j < len(points); j++ {
+			if ellipsoid {
+				g := point.GeodesicInverse(points[i], points[j])
+				fmt.Printf("  %s to %s: %.3f km, initial bearing %.2f°, final bearing %.2f°\n",
+					points[i], points[j], g.DistanceKm, g.InitialBearing, g.FinalBearing)
+				continue
+			}
+
 			dist := point.HaversineDistance(points[i], points[j])
 			fmt.Printf("  %s to %s: %.2f km\n",
 				points[i], points[j], dist)
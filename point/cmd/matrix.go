@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"point/point"
+	"strconv"
+)
+
+type matrixJSON struct {
+	Points      []string    `json:"points"`
+	DistancesKm [][]float64 `json:"distancesKm"`
+}
+
+// writeMatrix prints the distance matrix of points as CSV, with a header
+// row and column of the points in decimal degrees, or as JSON.
+func writeMatrix(w io.Writer, format string, points []point.Point, workers int, ellipsoid bool) error {
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("unknown matrix format %q, expected csv or json", format)
+	}
+
+	opts := point.MatrixOptions{Workers: workers}
+	if ellipsoid {
+		opts.Distance = point.VincentyDistance
+	}
+
+	matrix, err := point.DistanceMatrix(points, opts)
+	if err != nil {
+		return err
+	}
+
+	labels := make([]string, len(points))
+	for i, pt := range points {
+		labels[i] = pt.Format(point.StyleDecimal)
+	}
+
+	if format == "json" {
+		return json.NewEncoder(w).Encode(matrixJSON{Points: labels, DistancesKm: matrix})
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{""}, labels...)); err != nil {
+		return err
+	}
+
+	record := make([]string, len(points)+1)
+	for i, row := range matrix {
+		record[0] = labels[i]
+		for j, d := range row {
+			record[j+1] = strconv.FormatFloat(d, 'f', 3, 64)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"point/point"
+
+	"github.com/spf13/pflag"
+)
+
+// go run . bearing --from=55.7558,37.6176 --to=59.9311,30.3609
+// go run . destination --from=55.7558,37.6176 --bearing=320 --distance=633
+// go run . midpoint --from=55.7558,37.6176 --to=59.9311,30.3609
+// go run . intermediate --from=55.7558,37.6176 --to=40.7128,-74.0060 --segments=4
+// go run . intermediate --from=55.7558,37.6176 --to=40.7128,-74.0060 --fraction=0.25
+// go run . format --point='55°45'"'"'21"N 37°37'"'"'04"E' --style=wkt
+// go run . geohash --point=55.7558,37.6176 --precision=7 --neighbors
+// go run . geohash --hash=ucfv0j
+// go run . geohash --point=55.7558,37.6176 --precision=5 --radius=10
+
+var subcommands = map[string]func(args []string) error{
+	"bearing":      runBearing,
+	"destination":  runDestination,
+	"midpoint":     runMidpoint,
+	"intermediate": runIntermediate,
+	"format":       runFormat,
+	"geohash":      runGeohash,
+}
+
+func newFlagSet(name, usage string) *pflag.FlagSet {
+	fs := pflag.NewFlagSet(name, pflag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: point %s %s\n", name, usage)
+		fmt.Fprintf(os.Stderr, "\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func parseRoute(fs *pflag.FlagSet, args []string) (point.Point, point.Point, error) {
+	var from, to string
+	fs.StringVar(&from, "from", "", "Start point in format lat,lng")
+	fs.StringVar(&to, "to", "", "End point in format lat,lng")
+
+	if err := fs.Parse(args); err != nil {
+		return point.Point{}, point.Point{}, err
+	}
+
+	start, err := point.ParsePoint(from)
+	if err != nil {
+		return point.Point{}, point.Point{}, fmt.Errorf("error parsing --from: %w", err)
+	}
+
+	end, err := point.ParsePoint(to)
+	if err != nil {
+		return point.Point{}, point.Point{}, fmt.Errorf("error parsing --to: %w", err)
+	}
+
+	return start, end, nil
+}
+
+func runBearing(args []string) error {
+	fs := newFlagSet("bearing", "--from=lat,lng --to=lat,lng")
+
+	from, to, err := parseRoute(fs, args)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("From %s to %s:\n", from, to)
+	fmt.Printf("  Initial bearing: %.4f°\n", from.InitialBearing(to))
+	fmt.Printf("  Final bearing: %.4f°\n", from.FinalBearing(to))
+	return nil
+}
+
+func runDestination(args []string) error {
+	fs := newFlagSet("destination", "--from=lat,lng --bearing=degrees --distance=km")
+
+	var from string
+	var bearing, distanceKm float64
+	fs.StringVar(&from, "from", "", "Start point in format lat,lng")
+	fs.Float64Var(&bearing, "bearing", 0, "Initial bearing in degrees clockwise from north")
+	fs.Float64Var(&distanceKm, "distance", 0, "Distance to travel in km")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	start, err := point.ParsePoint(from)
+	if err != nil {
+		return fmt.Errorf("error parsing --from: %w", err)
+	}
+
+	fmt.Printf("Travelling %.2f km from %s at %.4f°:\n", distanceKm, start, bearing)
+	fmt.Printf("  Destination: %s\n", start.Destination(bearing, distanceKm))
+	return nil
+}
+
+func runMidpoint(args []string) error {
+	fs := newFlagSet("midpoint", "--from=lat,lng --to=lat,lng")
+
+	from, to, err := parseRoute(fs, args)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Midpoint between %s and %s:\n", from, to)
+	fmt.Printf("  %s\n", from.Midpoint(to))
+	return nil
+}
+
+func runIntermediate(args []string) error {
+	fs := newFlagSet("intermediate", "--from=lat,lng --to=lat,lng (--fraction=f | --segments=n)")
+
+	var fraction float64
+	var segments int
+	fs.Float64Var(&fraction, "fraction", -1, "Fraction of the way from --from to --to, between 0 and 1")
+	fs.IntVar(&segments, "segments", 0, "Split the great circle into this many equal segments")
+
+	from, to, err := parseRoute(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if segments > 0 {
+		points, err := from.IntermediatePoints(to, segments)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Great circle from %s to %s in %d segments:\n", from, to, segments)
+		for i, pt := range points {
+			fmt.Printf("  %d: %s\n", i, pt)
+		}
+		return nil
+	}
+
+	if fraction < 0 || fraction > 1 {
+		return fmt.Errorf("--fraction must be between 0 and 1, got %g", fraction)
+	}
+
+	pt, err := from.IntermediatePoint(to, fraction)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Point %.2f of the way from %s to %s:\n", fraction, from, to)
+	fmt.Printf("  %s\n", pt)
+	return nil
+}
+
+func runFormat(args []string) error {
+	fs := newFlagSet("format", "--point=<any supported format> [--point=...] --style=decimal|dms|ddm|geo|wkt")
+
+	var pointStrings []string
+	var styleName string
+	fs.StringArrayVar(&pointStrings, "point", []string{},
+		"Point as lat,lng, DMS, DDM, geo URI or WKT (can be specified multiple times)")
+	fs.StringVar(&styleName, "style", "decimal", "Output style: decimal, dms, ddm, geo or wkt")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	style, err := point.ParseStyle(styleName)
+	if err != nil {
+		return err
+	}
+
+	points, err := point.ParsePoints(pointStrings)
+	if err != nil {
+		return err
+	}
+
+	for _, pt := range points {
+		fmt.Println(pt.Format(style))
+	}
+	return nil
+}
+
+func runGeohash(args []string) error {
+	fs := newFlagSet("geohash", "(--point=lat,lng [--precision=n] [--neighbors | --radius=km] | --hash=geohash)")
+
+	var pointString, hash string
+	var precision int
+	var neighbors bool
+	var radiusKm float64
+	fs.StringVar(&pointString, "point", "", "Point to encode in any supported format")
+	fs.StringVar(&hash, "hash", "", "Geohash to decode")
+	fs.IntVar(&precision, "precision", 9, "Geohash length in characters, between 1 and 12")
+	fs.BoolVar(&neighbors, "neighbors", false, "Also print the 8 adjacent cells")
+	fs.Float64Var(&radiusKm, "radius", -1, "Print the cells intersecting this radius in km around --point")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if hash == "" {
+		pt, err := point.ParsePoint(pointString)
+		if err != nil {
+			return fmt.Errorf("error parsing --point: %w", err)
+		}
+
+		if radiusKm >= 0 {
+			cells, err := point.GeohashCover(pt, radiusKm, precision)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("%d cells within %.2f km of %s:\n", len(cells), radiusKm, pt)
+			for _, cell := range cells {
+				fmt.Printf("  %s\n", cell)
+			}
+			return nil
+		}
+
+		if hash, err = pt.Geohash(precision); err != nil {
+			return err
+		}
+	}
+
+	cell, err := point.FromGeohash(hash)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Geohash %s:\n", cell.Hash)
+	fmt.Printf("  Center: %s\n", cell.Center)
+	fmt.Printf("  South-west: %s\n", cell.SW)
+	fmt.Printf("  North-east: %s\n", cell.NE)
+
+	if neighbors {
+		adjacent, err := point.Neighbors(cell.Hash)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("  Neighbors:")
+		for i, direction := range []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"} {
+			if adjacent[i] != "" {
+				fmt.Printf("    %-2s %s\n", direction, adjacent[i])
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package point
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// BBox is a latitude/longitude box given by its south-west and north-east
+// corners. A box whose SW corner is east of its NE corner crosses the
+// antimeridian; one spanning -180 to 180 covers every longitude, which is
+// how boxes reaching a pole are represented.
+type BBox struct {
+	SW Point
+	NE Point
+}
+
+// NewBBox returns the box with the given corners.
+func NewBBox(sw, ne Point) (BBox, error) {
+	if err := validateCoordinates(sw.Latitude, sw.Longitude); err != nil {
+		return BBox{}, err
+	}
+	if err := validateCoordinates(ne.Latitude, ne.Longitude); err != nil {
+		return BBox{}, err
+	}
+	if sw.Latitude > ne.Latitude {
+		return BBox{}, fmt.Errorf("south-west latitude %f is north of north-east latitude %f", sw.Latitude, ne.Latitude)
+	}
+	return BBox{SW: sw, NE: ne}, nil
+}
+
+// BBoxFromRadius returns the smallest box containing every point within
+// radiusKm of center, so Contains can prefilter IsWithinRadius checks. The
+// radius is padded by a fraction of a millimetre so rounding never drops
+// points on the circle.
+func BBoxFromRadius(center Point, radiusKm float64) BBox {
+	if radiusKm < 0 {
+		return BBox{SW: center, NE: center}
+	}
+	return BBox{SW: center, NE: center}.Expand(radiusKm*(1+1e-12) + 1e-9)
+}
+
+// BBoxFromPoints returns the smallest box containing points. When they are
+// closer together across the antimeridian than around the globe the other
+// way, the box crosses the antimeridian.
+func BBoxFromPoints(points []Point) (BBox, error) {
+	if len(points) == 0 {
+		return BBox{}, ErrNoPoints
+	}
+
+	b := BBox{
+		SW: Point{Latitude: math.Inf(1)},
+		NE: Point{Latitude: math.Inf(-1)},
+	}
+	lngs := make([]float64, len(points))
+	for i, p := range points {
+		b.SW.Latitude = math.Min(b.SW.Latitude, p.Latitude)
+		b.NE.Latitude = math.Max(b.NE.Latitude, p.Latitude)
+		lngs[i] = p.Longitude
+	}
+	sort.Float64s(lngs)
+
+	// The box spans every longitude except the widest gap between
+	// neighbouring points, counting the gap that wraps past ±180°.
+	b.SW.Longitude, b.NE.Longitude = lngs[0], lngs[len(lngs)-1]
+	widest := 360 - (b.NE.Longitude - b.SW.Longitude)
+	for i := 1; i < len(lngs); i++ {
+		if gap := lngs[i] - lngs[i-1]; gap > widest {
+			widest = gap
+			b.SW.Longitude, b.NE.Longitude = lngs[i], lngs[i-1]
+		}
+	}
+
+	return b, nil
+}
+
+func (b BBox) String() string {
+	return fmt.Sprintf("BBox(sw=%s, ne=%s)", b.SW, b.NE)
+}
+
+// CrossesAntimeridian reports whether the box wraps past ±180°.
+func (b BBox) CrossesAntimeridian() bool {
+	return b.SW.Longitude > b.NE.Longitude
+}
+
+// Contains reports whether p lies inside the box or on its edge.
+func (b BBox) Contains(p Point) bool {
+	return inBBox(p, b.SW, b.NE)
+}
+
+// Intersects reports whether the boxes share at least one point.
+func (b BBox) Intersects(other BBox) bool {
+	if b.SW.Latitude > other.NE.Latitude || other.SW.Latitude > b.NE.Latitude {
+		return false
+	}
+
+	start, width := b.lngArc()
+	otherStart, otherWidth := other.lngArc()
+	return math.Mod(otherStart-start+360, 360) <= width ||
+		math.Mod(start-otherStart+360, 360) <= otherWidth
+}
+
+// Union returns the smallest box containing both boxes.
+func (b BBox) Union(other BBox) BBox {
+	result := BBox{
+		SW: Point{Latitude: math.Min(b.SW.Latitude, other.SW.Latitude)},
+		NE: Point{Latitude: math.Max(b.NE.Latitude, other.NE.Latitude)},
+	}
+
+	// Either arc's start can begin the union; take whichever is narrower.
+	start, width := b.lngArc()
+	otherStart, otherWidth := other.lngArc()
+	fromB := math.Max(width, math.Mod(otherStart-start+360, 360)+otherWidth)
+	fromOther := math.Max(otherWidth, math.Mod(start-otherStart+360, 360)+width)
+	if fromOther < fromB {
+		start, fromB = otherStart, fromOther
+	}
+
+	result.SW.Longitude, result.NE.Longitude = arcBounds(start, fromB)
+	return result
+}
+
+// Expand returns the smallest box containing every point within
+// distanceKm of the box. Boxes that reach a pole cover every longitude.
+// A negative distance is treated as zero.
+func (b BBox) Expand(distanceKm float64) BBox {
+	delta := math.Max(distanceKm, 0) / earthRadiusKm
+	result := BBox{
+		SW: Point{Latitude: b.SW.Latitude - toDegrees(delta)},
+		NE: Point{Latitude: b.NE.Latitude + toDegrees(delta)},
+	}
+
+	if result.SW.Latitude <= -90 || result.NE.Latitude >= 90 {
+		result.SW.Latitude = math.Max(result.SW.Latitude, -90)
+		result.NE.Latitude = math.Min(result.NE.Latitude, 90)
+		result.SW.Longitude, result.NE.Longitude = -180, 180
+		return result
+	}
+
+	// A circle's longitude span widens towards the poles, so the edge
+	// furthest from the equator sets how far the box grows east and west.
+	start, width := b.lngArc()
+	farthest := math.Max(math.Abs(b.SW.Latitude), math.Abs(b.NE.Latitude))
+	ratio := math.Sin(delta) / math.Cos(ToRadians(farthest))
+	if ratio >= 1 {
+		width = 360
+	} else {
+		span := toDegrees(math.Asin(ratio))
+		start, width = start-span, width+2*span
+	}
+
+	result.SW.Longitude, result.NE.Longitude = arcBounds(start, width)
+	return result
+}
+
+// lngArc returns the western edge of the box and its width in degrees of
+// longitude, measured eastwards.
+func (b BBox) lngArc() (float64, float64) {
+	if b.SW.Longitude == -180 && b.NE.Longitude == 180 {
+		return -180, 360
+	}
+	return b.SW.Longitude, math.Mod(b.NE.Longitude-b.SW.Longitude+360, 360)
+}
+
+// arcBounds converts an eastward arc of longitudes to box edges.
+func arcBounds(start, width float64) (float64, float64) {
+	if width >= 360 {
+		return -180, 180
+	}
+
+	west := NormalizeLongitude(start)
+	east := west + width
+	if east > 180 {
+		east -= 360
+	}
+	return west, east
+}
+
+// FilterWithinRadius returns the points within radiusKm of center, using
+// the radius bounding box to skip most Haversine computations.
+func FilterWithinRadius(points []Point, center Point, radiusKm float64) []Point {
+	if radiusKm < 0 {
+		return nil
+	}
+
+	box := BBoxFromRadius(center, radiusKm)
+
+	var result []Point
+	for _, p := range points {
+		if box.Contains(p) && p.IsWithinRadius(center, radiusKm) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
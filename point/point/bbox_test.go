@@ -0,0 +1,241 @@
+package point
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func box(swLat, swLng, neLat, neLng float64) BBox {
+	return BBox{
+		SW: Point{Latitude: swLat, Longitude: swLng},
+		NE: Point{Latitude: neLat, Longitude: neLng},
+	}
+}
+
+func TestNewBBox(t *testing.T) {
+	t.Parallel()
+
+	b, err := NewBBox(Point{Latitude: -10, Longitude: 170}, Point{Latitude: 10, Longitude: -170})
+	require.NoError(t, err)
+	require.True(t, b.CrossesAntimeridian())
+
+	_, err = NewBBox(Point{Latitude: 10, Longitude: 0}, Point{Latitude: -10, Longitude: 10})
+	require.Error(t, err)
+
+	_, err = NewBBox(Point{Latitude: 0, Longitude: 0}, Point{Latitude: 91, Longitude: 10})
+	require.Error(t, err)
+}
+
+func TestBBoxFromPoints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		points    []Point
+		sw        Point
+		ne        Point
+		shouldErr bool
+	}{
+		{
+			"Single point",
+			[]Point{{Latitude: 10, Longitude: 20}},
+			Point{Latitude: 10, Longitude: 20},
+			Point{Latitude: 10, Longitude: 20},
+			false,
+		},
+		{
+			"Regular route",
+			[]Point{{Latitude: 55.7558, Longitude: 37.6176}, {Latitude: 59.9311, Longitude: 30.3609}, {Latitude: 56.8431, Longitude: 60.6454}},
+			Point{Latitude: 55.7558, Longitude: 30.3609},
+			Point{Latitude: 59.9311, Longitude: 60.6454},
+			false,
+		},
+		{
+			"Crosses antimeridian",
+			[]Point{{Latitude: -17, Longitude: 178}, {Latitude: -14, Longitude: -172}, {Latitude: -18, Longitude: 179}},
+			Point{Latitude: -18, Longitude: 178},
+			Point{Latitude: -14, Longitude: -172},
+			false,
+		},
+		{"Empty", nil, Point{}, Point{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			box, err := BBoxFromPoints(tt.points)
+
+			if tt.shouldErr {
+				require.ErrorIs(t, err, ErrNoPoints)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, BBox{SW: tt.sw, NE: tt.ne}, box)
+			for _, p := range tt.points {
+				require.True(t, box.Contains(p))
+			}
+		})
+	}
+}
+
+func TestBBoxContains(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		box      BBox
+		point    Point
+		expected bool
+	}{
+		{"Inside", box(50, 30, 60, 40), Point{Latitude: 55, Longitude: 35}, true},
+		{"On edge", box(50, 30, 60, 40), Point{Latitude: 60, Longitude: 30}, true},
+		{"Outside longitude", box(50, 30, 60, 40), Point{Latitude: 55, Longitude: 41}, false},
+		{"Outside latitude", box(50, 30, 60, 40), Point{Latitude: 49, Longitude: 35}, false},
+		{"Across antimeridian east", box(-10, 170, 10, -170), Point{Latitude: 0, Longitude: 175}, true},
+		{"Across antimeridian west", box(-10, 170, 10, -170), Point{Latitude: 0, Longitude: -175}, true},
+		{"Across antimeridian on 180", box(-10, 170, 10, -170), Point{Latitude: 0, Longitude: 180}, true},
+		{"Across antimeridian outside", box(-10, 170, 10, -170), Point{Latitude: 0, Longitude: 0}, false},
+		{"Polar cap", box(80, -180, 90, 180), Point{Latitude: 90, Longitude: 123}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, tt.box.Contains(tt.point))
+		})
+	}
+}
+
+func TestBBoxIntersects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		a        BBox
+		b        BBox
+		expected bool
+	}{
+		{"Overlapping", box(0, 0, 10, 10), box(5, 5, 15, 15), true},
+		{"Touching edges", box(0, 0, 10, 10), box(10, 10, 20, 20), true},
+		{"Contained", box(0, 0, 10, 10), box(2, 2, 3, 3), true},
+		{"Disjoint longitude", box(0, 0, 10, 10), box(0, 20, 10, 30), false},
+		{"Disjoint latitude", box(0, 0, 10, 10), box(20, 0, 30, 10), false},
+		{"Both across antimeridian", box(-10, 170, 10, -170), box(-5, 175, 5, -160), true},
+		{"One across antimeridian", box(-10, 170, 10, -170), box(-5, -175, 5, -160), true},
+		{"Across antimeridian disjoint", box(-10, 170, 10, -170), box(-5, -160, 5, 160), false},
+		{"Whole longitude range", box(-10, -180, 10, 180), box(-5, 100, 5, 110), true},
+		{"Polar caps", box(80, -180, 90, 180), box(-90, -180, -80, 180), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, tt.a.Intersects(tt.b))
+			require.Equal(t, tt.expected, tt.b.Intersects(tt.a))
+		})
+	}
+}
+
+func TestBBoxUnion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		a        BBox
+		b        BBox
+		expected BBox
+	}{
+		{"Disjoint", box(0, 0, 10, 10), box(20, 30, 30, 40), box(0, 0, 30, 40)},
+		{"Contained", box(0, 0, 10, 10), box(2, 2, 3, 3), box(0, 0, 10, 10)},
+		{"Shorter across antimeridian", box(0, 170, 10, 175), box(0, -175, 10, -170), box(0, 170, 10, -170)},
+		{"Extends box across antimeridian", box(-10, 170, 10, -170), box(0, -165, 20, -160), box(-10, 170, 20, -160)},
+		{"Overlapping arcs cover globe", box(0, -100, 10, 100), box(0, 90, 10, -90), box(0, -180, 10, 180)},
+		{"Whole longitude range", box(-10, -180, 10, 180), box(20, 5, 30, 6), box(-10, -180, 30, 180)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, tt.a.Union(tt.b))
+			require.Equal(t, tt.expected, tt.b.Union(tt.a))
+		})
+	}
+}
+
+func TestBBoxExpand(t *testing.T) {
+	t.Parallel()
+
+	// One degree of latitude is 2πR/360 km.
+	degree := HaversineDistance(Point{}, Point{Latitude: 1})
+
+	expanded := box(0, 0, 10, 10).Expand(degree)
+	require.InDelta(t, -1, expanded.SW.Latitude, 1e-9)
+	require.InDelta(t, 11, expanded.NE.Latitude, 1e-9)
+	require.Less(t, expanded.SW.Longitude, -1.0)
+	require.Greater(t, expanded.NE.Longitude, 11.0)
+
+	across := box(0, 178, 1, 179.5).Expand(degree)
+	require.True(t, across.CrossesAntimeridian())
+	require.Less(t, across.NE.Longitude, -179.0)
+
+	polar := box(88, 10, 89, 20).Expand(2 * degree)
+	require.Equal(t, box(86, -180, 90, 180), polar)
+
+	require.Equal(t, box(0, 0, 10, 10), box(0, 0, 10, 10).Expand(-5))
+}
+
+func TestBBoxFromRadiusContainsCircle(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(15, 16))
+	centers := append(randomPoints(r, 50),
+		Point{Latitude: 0, Longitude: 179.9},
+		Point{Latitude: 89.5, Longitude: 0},
+		Point{Latitude: -85, Longitude: -179},
+	)
+
+	for _, center := range centers {
+		for _, radius := range []float64{0, 1, 100, 1000, 5000} {
+			b := BBoxFromRadius(center, radius)
+			require.True(t, b.Contains(center))
+
+			for i := 0; i < 200; i++ {
+				p := center.Destination(r.Float64()*360, radius*r.Float64())
+				require.True(t, b.Contains(p), "%s does not contain %s", b, p)
+			}
+
+			// Points on the circle at the widest longitude must stay inside.
+			for bearing := 0.0; bearing < 360; bearing += 0.5 {
+				p := center.Destination(bearing, radius*(1-1e-9))
+				require.True(t, b.Contains(p), "%s does not contain %s", b, p)
+			}
+		}
+	}
+}
+
+func TestFilterWithinRadius(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(17, 18))
+	points := randomPoints(r, 5000)
+
+	for _, center := range randomPoints(r, 20) {
+		for _, radius := range []float64{-1, 0, 500, 3000, 20100} {
+			var expected []Point
+			for _, p := range points {
+				if p.IsWithinRadius(center, radius) {
+					expected = append(expected, p)
+				}
+			}
+
+			require.Equal(t, expected, FilterWithinRadius(points, center, radius))
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package point
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Style selects the textual representation of a Point.
+type Style int
+
+const (
+	// StyleDecimal is decimal degrees, "55.7558,37.6176".
+	StyleDecimal Style = iota
+	// StyleDMS is degrees, minutes and seconds, `55°45'20.880"N 37°37'03.360"E`.
+	StyleDMS
+	// StyleDDM is degrees and decimal minutes, "55°45.3480'N 37°37.0560'E".
+	StyleDDM
+	// StyleGeoURI is an RFC 5870 geo URI, "geo:55.7558,37.6176".
+	StyleGeoURI
+	// StyleWKT is a Well-Known Text point, "POINT(37.6176 55.7558)".
+	StyleWKT
+)
+
+func (s Style) String() string {
+	switch s {
+	case StyleDecimal:
+		return "decimal"
+	case StyleDMS:
+		return "dms"
+	case StyleDDM:
+		return "ddm"
+	case StyleGeoURI:
+		return "geo"
+	case StyleWKT:
+		return "wkt"
+	default:
+		return fmt.Sprintf("Style(%d)", int(s))
+	}
+}
+
+// ParseStyle returns the style with the given name as reported by
+// Style.String.
+func ParseStyle(name string) (Style, error) {
+	for _, s := range []Style{StyleDecimal, StyleDMS, StyleDDM, StyleGeoURI, StyleWKT} {
+		if s.String() == strings.ToLower(name) {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown style %q, expected decimal, dms, ddm, geo or wkt", name)
+}
+
+const sexagesimalCoordinate = `(\d+(?:\.\d+)?)\s*°\s*` +
+	`(?:(\d+(?:\.\d+)?)\s*['′]\s*)?` +
+	`(?:(\d+(?:\.\d+)?)\s*(?:"|″|'')\s*)?` +
+	`([NSEWnsew])`
+
+var (
+	sexagesimalPattern = regexp.MustCompile(`^` + sexagesimalCoordinate + `[\s,]*` + sexagesimalCoordinate + `$`)
+	geoURIPattern      = regexp.MustCompile(`(?i)^geo:([^,;]+),([^,;]+)(?:,[^,;]+)?((?:;[^;]*)*)$`)
+	wktPattern         = regexp.MustCompile(`(?i)^point\s*\(\s*(\S+)\s+(\S+)\s*\)$`)
+)
+
+// ParsePoint parses a point in any of the supported styles, detected from
+// the input: decimal "lat,lng", DMS or DDM with hemisphere letters, a geo
+// URI or a WKT POINT(lng lat).
+func ParsePoint(s string) (Point, error) {
+	trimmed := strings.TrimSpace(s)
+	lower := strings.ToLower(trimmed)
+
+	switch {
+	case strings.HasPrefix(lower, "geo:"):
+		return parseGeoURI(trimmed)
+	case strings.HasPrefix(lower, "point"):
+		return parseWKT(trimmed)
+	case strings.Contains(trimmed, "°"):
+		return parseSexagesimal(trimmed)
+	default:
+		return parseDecimal(s)
+	}
+}
+
+func parseDecimal(s string) (Point, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return Point{}, fmt.Errorf("invalid point format: %s, expected lat,lng", s)
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid latitude: %s", parts[0])
+	}
+
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid longitude: %s", parts[1])
+	}
+
+	return New(lat, lng)
+}
+
+func parseGeoURI(s string) (Point, error) {
+	m := geoURIPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Point{}, fmt.Errorf("invalid geo URI: %s, expected geo:lat,lng", s)
+	}
+
+	for _, param := range strings.Split(m[3], ";")[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "crs") && !strings.EqualFold(value, "wgs84") {
+			return Point{}, fmt.Errorf("unsupported geo URI crs: %s", value)
+		}
+	}
+
+	return parseDecimal(m[1] + "," + m[2])
+}
+
+func parseWKT(s string) (Point, error) {
+	m := wktPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Point{}, fmt.Errorf("invalid WKT point: %s, expected POINT(lng lat)", s)
+	}
+
+	lng, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid longitude: %s", m[1])
+	}
+
+	lat, err := strconv.ParseFloat(m[2], 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid latitude: %s", m[2])
+	}
+
+	return New(lat, lng)
+}
+
+func parseSexagesimal(s string) (Point, error) {
+	m := sexagesimalPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Point{}, fmt.Errorf(`invalid DMS point: %s, expected e.g. 55°45'21"N 37°37'04"E`, s)
+	}
+
+	first, firstHemisphere, err := parseSexagesimalCoordinate(m[1:5])
+	if err != nil {
+		return Point{}, err
+	}
+
+	second, secondHemisphere, err := parseSexagesimalCoordinate(m[5:9])
+	if err != nil {
+		return Point{}, err
+	}
+
+	switch {
+	case isLatitudeHemisphere(firstHemisphere) && !isLatitudeHemisphere(secondHemisphere):
+		return New(first, second)
+	case !isLatitudeHemisphere(firstHemisphere) && isLatitudeHemisphere(secondHemisphere):
+		return New(second, first)
+	default:
+		return Point{}, fmt.Errorf("invalid DMS point: %s, expected one N/S and one E/W coordinate", s)
+	}
+}
+
+// parseSexagesimalCoordinate converts the degrees, minutes, seconds and
+// hemisphere captures of one coordinate to signed decimal degrees.
+func parseSexagesimalCoordinate(parts []string) (float64, byte, error) {
+	degrees, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid degrees: %s", parts[0])
+	}
+
+	var minutes, seconds float64
+	if parts[1] != "" {
+		if minutes, err = strconv.ParseFloat(parts[1], 64); err != nil || minutes >= 60 {
+			return 0, 0, fmt.Errorf("invalid minutes: %s", parts[1])
+		}
+	}
+	if parts[2] != "" {
+		if seconds, err = strconv.ParseFloat(parts[2], 64); err != nil || seconds >= 60 {
+			return 0, 0, fmt.Errorf("invalid seconds: %s", parts[2])
+		}
+	}
+
+	value := degrees + minutes/60 + seconds/3600
+	hemisphere := strings.ToUpper(parts[3])[0]
+	if hemisphere == 'S' || hemisphere == 'W' {
+		value = -value
+	}
+
+	return value, hemisphere, nil
+}
+
+func isLatitudeHemisphere(h byte) bool {
+	return h == 'N' || h == 'S'
+}
+
+// Format returns p in the given style. Decimal, geo URI and WKT output use
+// the shortest representation that parses back to the same coordinates;
+// DMS is rounded to milliseconds of arc and DDM to 1e-4 minutes.
+func (p Point) Format(style Style) string {
+	switch style {
+	case StyleDMS:
+		return formatDMS(p.Latitude, 'N', 'S') + " " + formatDMS(p.Longitude, 'E', 'W')
+	case StyleDDM:
+		return formatDDM(p.Latitude, 'N', 'S') + " " + formatDDM(p.Longitude, 'E', 'W')
+	case StyleGeoURI:
+		return "geo:" + formatDecimal(p.Latitude) + "," + formatDecimal(p.Longitude)
+	case StyleWKT:
+		return "POINT(" + formatDecimal(p.Longitude) + " " + formatDecimal(p.Latitude) + ")"
+	default:
+		return formatDecimal(p.Latitude) + "," + formatDecimal(p.Longitude)
+	}
+}
+
+func formatDecimal(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func hemisphere(v float64, positive, negative byte) byte {
+	if v < 0 {
+		return negative
+	}
+	return positive
+}
+
+func formatDMS(v float64, positive, negative byte) string {
+	// Work in whole milliseconds of arc so rounding carries into minutes
+	// and degrees instead of producing 60 seconds.
+	total := int64(math.Round(math.Abs(v) * 3600 * 1000))
+	degrees := total / 3600000
+	minutes := total / 60000 % 60
+	millis := total % 60000
+
+	return fmt.Sprintf(`%d°%02d'%02d.%03d"%c`,
+		degrees, minutes, millis/1000, millis%1000, hemisphere(v, positive, negative))
+}
+
+func formatDDM(v float64, positive, negative byte) string {
+	// Work in units of 1e-4 minutes for the same reason as formatDMS.
+	total := int64(math.Round(math.Abs(v) * 60 * 10000))
+	degrees := total / 600000
+	fraction := total % 600000
+
+	return fmt.Sprintf(`%d°%02d.%04d'%c`,
+		degrees, fraction/10000, fraction%10000, hemisphere(v, positive, negative))
+}
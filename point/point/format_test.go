@@ -0,0 +1,152 @@
+package point
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePointFormats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		expected  Point
+		shouldErr bool
+	}{
+		{"Decimal", "55.75,37.61", Point{Latitude: 55.75, Longitude: 37.61}, false},
+		{"DMS", `55°45'21"N 37°37'04"E`, Point{Latitude: dms(55, 45, 21), Longitude: dms(37, 37, 4)}, false},
+		{"DMS with comma", `55°45'21"N, 37°37'04"E`, Point{Latitude: dms(55, 45, 21), Longitude: dms(37, 37, 4)}, false},
+		{"DMS with spaces", `55° 45' 21" N 37° 37' 04" E`, Point{Latitude: dms(55, 45, 21), Longitude: dms(37, 37, 4)}, false},
+		{"DMS with primes", `55°45′21″N 37°37′04″E`, Point{Latitude: dms(55, 45, 21), Longitude: dms(37, 37, 4)}, false},
+		{"DMS fractional seconds", `33°52'07.68"S 151°12'33.48"E`, Point{Latitude: -dms(33, 52, 7.68), Longitude: dms(151, 12, 33.48)}, false},
+		{"DMS longitude first", `74°00'21.6"W 40°42'46.08"N`, Point{Latitude: dms(40, 42, 46.08), Longitude: -dms(74, 0, 21.6)}, false},
+		{"DMS lowercase hemisphere", `55°45'21"n 37°37'04"w`, Point{Latitude: dms(55, 45, 21), Longitude: -dms(37, 37, 4)}, false},
+		{"DDM", `55°45.35'N 37°37.0667'E`, Point{Latitude: 55 + 45.35/60, Longitude: 37 + 37.0667/60}, false},
+		{"Degrees only", `55.5°N 37.25°W`, Point{Latitude: 55.5, Longitude: -37.25}, false},
+		{"Geo URI", "geo:55.75,37.61", Point{Latitude: 55.75, Longitude: 37.61}, false},
+		{"Geo URI with altitude", "geo:55.75,37.61,120", Point{Latitude: 55.75, Longitude: 37.61}, false},
+		{"Geo URI with parameters", "GEO:55.75,37.61;crs=wgs84;u=35", Point{Latitude: 55.75, Longitude: 37.61}, false},
+		{"WKT", "POINT(37.61 55.75)", Point{Latitude: 55.75, Longitude: 37.61}, false},
+		{"WKT lowercase with spaces", "  point ( -74.006  40.7128 ) ", Point{Latitude: 40.7128, Longitude: -74.006}, false},
+		{"DMS two latitudes", `55°45'21"N 37°37'04"S`, Point{}, true},
+		{"DMS missing hemisphere", `55°45'21" 37°37'04"E`, Point{}, true},
+		{"DMS minutes out of range", `55°61'21"N 37°37'04"E`, Point{}, true},
+		{"DMS seconds out of range", `55°45'60"N 37°37'04"E`, Point{}, true},
+		{"DMS latitude out of range", `95°00'00"N 37°37'04"E`, Point{}, true},
+		{"Geo URI other crs", "geo:55.75,37.61;crs=nad27", Point{}, true},
+		{"Geo URI missing longitude", "geo:55.75", Point{}, true},
+		{"Geo URI invalid number", "geo:north,37.61", Point{}, true},
+		{"WKT missing coordinate", "POINT(37.61)", Point{}, true},
+		{"WKT comma separated", "POINT(37.61, 55.75)", Point{}, true},
+		{"WKT out of range", "POINT(37.61 95)", Point{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ParsePoint(tt.input)
+
+			if tt.shouldErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.InDelta(t, tt.expected.Latitude, result.Latitude, 1e-12)
+			require.InDelta(t, tt.expected.Longitude, result.Longitude, 1e-12)
+		})
+	}
+}
+
+func TestPointFormat(t *testing.T) {
+	t.Parallel()
+
+	moscow := Point{Latitude: 55.7558, Longitude: 37.6176}
+	sydney := Point{Latitude: -33.8688, Longitude: 151.2093}
+
+	tests := []struct {
+		name     string
+		point    Point
+		style    Style
+		expected string
+	}{
+		{"Decimal", moscow, StyleDecimal, "55.7558,37.6176"},
+		{"DMS", moscow, StyleDMS, `55°45'20.880"N 37°37'03.360"E`},
+		{"DDM", moscow, StyleDDM, `55°45.3480'N 37°37.0560'E`},
+		{"Geo URI", moscow, StyleGeoURI, "geo:55.7558,37.6176"},
+		{"WKT", moscow, StyleWKT, "POINT(37.6176 55.7558)"},
+		{"DMS southern hemisphere", sydney, StyleDMS, `33°52'07.680"S 151°12'33.480"E`},
+		{"DDM western hemisphere", Point{Latitude: 40.7128, Longitude: -74.006}, StyleDDM, `40°42.7680'N 74°00.3600'W`},
+		{"DMS carries rounding", Point{Latitude: 10.9999999999, Longitude: 0}, StyleDMS, `11°00'00.000"N 0°00'00.000"E`},
+		{"DDM carries rounding", Point{Latitude: 0, Longitude: -20.99999999}, StyleDDM, `0°00.0000'N 21°00.0000'W`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, tt.point.Format(tt.style))
+		})
+	}
+}
+
+func TestPointFormatRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	points := []Point{
+		{Latitude: 55.7558, Longitude: 37.6176},
+		{Latitude: -33.8688, Longitude: 151.2093},
+		{Latitude: 40.7128, Longitude: -74.006},
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 90, Longitude: 180},
+		{Latitude: -90, Longitude: -180},
+		{Latitude: 12.345678901234, Longitude: -98.765432109876},
+	}
+
+	tests := []struct {
+		style     Style
+		tolerance float64
+	}{
+		{StyleDecimal, 0},
+		{StyleGeoURI, 0},
+		{StyleWKT, 0},
+		{StyleDMS, 0.0005 / 3600},
+		{StyleDDM, 0.00005 / 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style.String(), func(t *testing.T) {
+			t.Parallel()
+
+			for _, p := range points {
+				s := p.Format(tt.style)
+
+				result, err := ParsePoint(s)
+				require.NoError(t, err, s)
+				require.InDelta(t, p.Latitude, result.Latitude, tt.tolerance, s)
+				require.InDelta(t, p.Longitude, result.Longitude, tt.tolerance, s)
+			}
+		})
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []Style{StyleDecimal, StyleDMS, StyleDDM, StyleGeoURI, StyleWKT} {
+		result, err := ParseStyle(s.String())
+		require.NoError(t, err)
+		require.Equal(t, s, result)
+	}
+
+	result, err := ParseStyle("WKT")
+	require.NoError(t, err)
+	require.Equal(t, StyleWKT, result)
+
+	_, err = ParseStyle("mgrs")
+	require.Error(t, err)
+	require.Equal(t, "Style(42)", Style(42).String())
+}
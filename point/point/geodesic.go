@@ -0,0 +1,198 @@
+package point
+
+import "math"
+
+// WGS-84 ellipsoid parameters: the semi-major axis in kilometres and the
+// flattening.
+const (
+	WGS84SemiMajorAxisKm = 6378.137
+	WGS84Flattening      = 1 / 298.257223563
+)
+
+// wgs84B is the semi-minor axis in kilometres.
+const wgs84B = WGS84SemiMajorAxisKm * (1 - WGS84Flattening)
+
+const (
+	vincentyMaxIterations = 200
+	vincentyTolerance     = 1e-12
+)
+
+// Geodesic is the solution of the inverse geodesic problem between two
+// points. Bearings are in degrees clockwise from north in [0, 360).
+type Geodesic struct {
+	DistanceKm     float64
+	InitialBearing float64
+	FinalBearing   float64
+}
+
+// VincentyDistance returns the length in kilometres of the shortest path
+// between p1 and p2 on the WGS-84 ellipsoid.
+func VincentyDistance(p1, p2 Point) float64 {
+	return GeodesicInverse(p1, p2).DistanceKm
+}
+
+// GeodesicInverse solves the inverse geodesic problem on the WGS-84
+// ellipsoid with Vincenty's formulae. Vincenty's iteration does not
+// converge for nearly antipodal points; for those the geodesic is split at
+// the midpoint that minimizes the total length, which keeps both halves
+// well away from the antipodal case.
+func GeodesicInverse(p1, p2 Point) Geodesic {
+	if g, ok := vincentyInverse(p1, p2); ok {
+		return g
+	}
+	return splitInverse(p1, p2)
+}
+
+func vincentyInverse(p1, p2 Point) (Geodesic, bool) {
+	u1 := math.Atan((1 - WGS84Flattening) * math.Tan(ToRadians(p1.Latitude)))
+	u2 := math.Atan((1 - WGS84Flattening) * math.Tan(ToRadians(p2.Latitude)))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	l := ToRadians(NormalizeLongitude(p2.Longitude - p1.Longitude))
+	lambda := l
+
+	var (
+		sinLambda, cosLambda float64
+		sinSigma, cosSigma   float64
+		sigma, cosSqAlpha    float64
+		cos2SigmaM           float64
+		converged            bool
+	)
+
+	for i := 0; i < vincentyMaxIterations; i++ {
+		sinLambda, cosLambda = math.Sincos(lambda)
+
+		x := cosU2 * sinLambda
+		y := cosU1*sinU2 - sinU1*cosU2*cosLambda
+		sinSigma = math.Sqrt(x*x + y*y)
+		if sinSigma == 0 {
+			return Geodesic{}, true
+		}
+
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+
+		// Along the equator cos²α is zero and so is the midpoint term.
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		c := WGS84Flattening / 16 * cosSqAlpha * (4 + WGS84Flattening*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = l + (1-c)*WGS84Flattening*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.IsNaN(lambda) || math.Abs(lambda) > math.Pi {
+			return Geodesic{}, false
+		}
+		if math.Abs(lambda-prev) < vincentyTolerance {
+			converged = true
+			break
+		}
+	}
+
+	if !converged {
+		return Geodesic{}, false
+	}
+
+	uSq := cosSqAlpha * (WGS84SemiMajorAxisKm*WGS84SemiMajorAxisKm - wgs84B*wgs84B) / (wgs84B * wgs84B)
+	a := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	b := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := b * sinSigma * (cos2SigmaM + b/4*
+		(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	initial := math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+	final := math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda)
+
+	return Geodesic{
+		DistanceKm:     wgs84B * a * (sigma - deltaSigma),
+		InitialBearing: normalizeBearing(toDegrees(initial)),
+		FinalBearing:   normalizeBearing(toDegrees(final)),
+	}, true
+}
+
+// splitInverse finds the point m minimizing |p1 m| + |m p2|, which lies on
+// the geodesic between p1 and p2. The minimum is flat, so errors in m only
+// affect the distance quadratically.
+func splitInverse(p1, p2 Point) Geodesic {
+	best := math.Inf(1)
+	var mid Point
+
+	for lat := -90.0; lat <= 90; lat += 15 {
+		for lng := -180.0; lng < 180; lng += 15 {
+			m := Point{Latitude: lat, Longitude: lng}
+			if d := splitDistance(p1, p2, m); d < best {
+				best, mid = d, m
+			}
+		}
+	}
+
+	directions := [][2]float64{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+	for step := 7.5; step > 1e-10; {
+		improved := false
+		for _, dir := range directions {
+			m := Point{
+				Latitude:  math.Max(-90, math.Min(90, mid.Latitude+dir[0]*step)),
+				Longitude: NormalizeLongitude(mid.Longitude + dir[1]*step),
+			}
+			if d := splitDistance(p1, p2, m); d < best {
+				best, mid, improved = d, m, true
+			}
+		}
+		if !improved {
+			step /= 2
+		}
+	}
+
+	first, _ := vincentyInverse(p1, mid)
+	second, _ := vincentyInverse(mid, p2)
+
+	return Geodesic{
+		DistanceKm:     first.DistanceKm + second.DistanceKm,
+		InitialBearing: first.InitialBearing,
+		FinalBearing:   second.FinalBearing,
+	}
+}
+
+func splitDistance(p1, p2, m Point) float64 {
+	first, ok := vincentyInverse(p1, m)
+	if !ok {
+		return math.Inf(1)
+	}
+	second, ok := vincentyInverse(m, p2)
+	if !ok {
+		return math.Inf(1)
+	}
+	return first.DistanceKm + second.DistanceKm
+}
+
+func toDegrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// normalizeBearing maps an angle in degrees to [0, 360).
+func normalizeBearing(degrees float64) float64 {
+	b := math.Mod(degrees, 360)
+	if b < 0 {
+		b += 360
+	}
+	if b == 360 {
+		b = 0
+	}
+	return b
+}
+
+// NormalizeLongitude maps an angle in degrees to [-180, 180]. Angles already
+// in that range, including both ends, are returned unchanged.
+func NormalizeLongitude(degrees float64) float64 {
+	if degrees >= -180 && degrees <= 180 {
+		return degrees
+	}
+	return math.Mod(math.Mod(degrees+180, 360)+360, 360) - 180
+}
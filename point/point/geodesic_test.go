@@ -0,0 +1,208 @@
+package point
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func dms(deg, min, sec float64) float64 {
+	if deg < 0 {
+		return deg - min/60 - sec/3600
+	}
+	return deg + min/60 + sec/3600
+}
+
+func TestGeodesicInverse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		p1               Point
+		p2               Point
+		distanceKm       float64
+		initialBearing   float64
+		finalBearing     float64
+		distanceTol      float64
+		bearingTolerance float64
+	}{
+		{
+			// Geoscience Australia's worked example for Vincenty's formulae.
+			"Flinders Peak to Buninyong",
+			Point{Latitude: dms(-37, 57, 3.72030), Longitude: dms(144, 25, 29.52440)},
+			Point{Latitude: dms(-37, 39, 10.15610), Longitude: dms(143, 55, 35.38390)},
+			54.972271,
+			dms(306, 52, 5.37),
+			dms(127, 10, 25.07) + 180,
+			1e-6,
+			1e-5,
+		},
+		{
+			"Meridian quadrant",
+			Point{Latitude: 0, Longitude: 0},
+			Point{Latitude: 90, Longitude: 0},
+			10001.965729,
+			0,
+			0,
+			1e-6,
+			1e-9,
+		},
+		{
+			"One degree along the equator",
+			Point{Latitude: 0, Longitude: 0},
+			Point{Latitude: 0, Longitude: 1},
+			111.319491,
+			90,
+			90,
+			1e-6,
+			1e-9,
+		},
+		{
+			"Across the antimeridian",
+			Point{Latitude: 0, Longitude: 179.5},
+			Point{Latitude: 0, Longitude: -179.5},
+			111.319491,
+			90,
+			90,
+			1e-6,
+			1e-9,
+		},
+		{
+			"Antipodal points on the equator",
+			Point{Latitude: 0, Longitude: 0},
+			Point{Latitude: 0, Longitude: 180},
+			20003.931459,
+			180,
+			0,
+			1e-6,
+			1e-9,
+		},
+		{
+			// Karney, "Algorithms for geodesics" (2013), where Vincenty fails.
+			"Nearly antipodal points",
+			Point{Latitude: -30, Longitude: 0},
+			Point{Latitude: 29.9, Longitude: 179.8},
+			19989.832828,
+			161.890525,
+			18.090737,
+			1e-6,
+			1e-4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := GeodesicInverse(tt.p1, tt.p2)
+			require.InDelta(t, tt.distanceKm, g.DistanceKm, tt.distanceTol)
+			require.InDelta(t, tt.initialBearing, g.InitialBearing, tt.bearingTolerance)
+			require.InDelta(t, tt.finalBearing, g.FinalBearing, tt.bearingTolerance)
+
+			require.InDelta(t, g.DistanceKm, VincentyDistance(tt.p2, tt.p1), tt.distanceTol)
+		})
+	}
+}
+
+func TestGeodesicInverseSamePoint(t *testing.T) {
+	t.Parallel()
+
+	p := Point{Latitude: 55.7558, Longitude: 37.6176}
+	require.Equal(t, Geodesic{}, GeodesicInverse(p, p))
+}
+
+func TestVincentyDistanceVsHaversine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		p1   Point
+		p2   Point
+	}{
+		{"Moscow to Saint Petersburg", Point{Latitude: 55.7558, Longitude: 37.6176}, Point{Latitude: 59.9311, Longitude: 30.3609}},
+		{"Berlin to Paris", Point{Latitude: 52.5200, Longitude: 13.4050}, Point{Latitude: 48.8566, Longitude: 2.3522}},
+		{"Along a meridian", Point{Latitude: -60, Longitude: 10}, Point{Latitude: 60, Longitude: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			vincenty := VincentyDistance(tt.p1, tt.p2)
+			haversine := HaversineDistance(tt.p1, tt.p2)
+			require.InEpsilon(t, haversine, vincenty, 0.005)
+		})
+	}
+}
+
+func TestSplitInverseMatchesVincenty(t *testing.T) {
+	t.Parallel()
+
+	p1 := Point{Latitude: 10, Longitude: 20}
+	p2 := Point{Latitude: -30, Longitude: 100}
+
+	direct, ok := vincentyInverse(p1, p2)
+	require.True(t, ok)
+
+	split := splitInverse(p1, p2)
+	require.InDelta(t, direct.DistanceKm, split.DistanceKm, 1e-6)
+	require.InDelta(t, direct.InitialBearing, split.InitialBearing, 1e-4)
+	require.InDelta(t, direct.FinalBearing, split.FinalBearing, 1e-4)
+}
+
+func TestVincentyInverseAntipodalFails(t *testing.T) {
+	t.Parallel()
+
+	_, ok := vincentyInverse(Point{Latitude: -30, Longitude: 0}, Point{Latitude: 29.9, Longitude: 179.8})
+	require.False(t, ok)
+}
+
+func TestNormalizeBearing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		degrees  float64
+		expected float64
+	}{
+		{"Zero", 0, 0},
+		{"Inside range", 123.5, 123.5},
+		{"Negative", -90, 270},
+		{"Full turn", 360, 0},
+		{"Beyond full turn", 450, 90},
+		{"Tiny negative", -1e-20, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.InDelta(t, tt.expected, normalizeBearing(tt.degrees), 1e-12)
+		})
+	}
+}
+
+func TestNormalizeLongitude(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		degrees  float64
+		expected float64
+	}{
+		{"Inside range", 37.6, 37.6},
+		{"Boundary", 180, 180},
+		{"Negative boundary", -180, -180},
+		{"East overflow", 190, -170},
+		{"West overflow", -190, 170},
+		{"Multiple turns", 900, 180 - 360},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.InDelta(t, tt.expected, NormalizeLongitude(tt.degrees), 1e-12)
+		})
+	}
+}
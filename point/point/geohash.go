@@ -0,0 +1,255 @@
+package point
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Supported geohash lengths; 12 characters resolve to a few centimetres.
+const (
+	MinGeohashPrecision = 1
+	MaxGeohashPrecision = 12
+)
+
+// maxCoverCells bounds the number of cells GeohashCover may return.
+const maxCoverCells = 1 << 16
+
+// GeohashCell is the rectangle identified by a geohash.
+type GeohashCell struct {
+	Hash   string
+	SW     Point
+	NE     Point
+	Center Point
+}
+
+// Geohash encodes p as a geohash with precision characters.
+func (p Point) Geohash(precision int) (string, error) {
+	if precision < MinGeohashPrecision || precision > MaxGeohashPrecision {
+		return "", fmt.Errorf("geohash precision must be between %d and %d, got %d",
+			MinGeohashPrecision, MaxGeohashPrecision, precision)
+	}
+
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+
+	var sb strings.Builder
+	bit, ch := 0, 0
+	even := true
+
+	for sb.Len() < precision {
+		r, v := &latRange, p.Latitude
+		if even {
+			r, v = &lngRange, p.Longitude
+		}
+
+		mid := (r[0] + r[1]) / 2
+		ch <<= 1
+		if v >= mid {
+			ch |= 1
+			r[0] = mid
+		} else {
+			r[1] = mid
+		}
+		even = !even
+
+		if bit++; bit == 5 {
+			sb.WriteByte(geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// FromGeohash decodes hash into the cell it identifies.
+func FromGeohash(hash string) (GeohashCell, error) {
+	if len(hash) < MinGeohashPrecision || len(hash) > MaxGeohashPrecision {
+		return GeohashCell{}, fmt.Errorf("geohash length must be between %d and %d, got %q",
+			MinGeohashPrecision, MaxGeohashPrecision, hash)
+	}
+
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+	even := true
+
+	for i := 0; i < len(hash); i++ {
+		idx := strings.IndexByte(geohashAlphabet, toLowerASCII(hash[i]))
+		if idx < 0 {
+			return GeohashCell{}, fmt.Errorf("invalid geohash character %q at position %d in %q", hash[i], i, hash)
+		}
+
+		for mask := 16; mask > 0; mask >>= 1 {
+			r := &latRange
+			if even {
+				r = &lngRange
+			}
+
+			mid := (r[0] + r[1]) / 2
+			if idx&mask != 0 {
+				r[0] = mid
+			} else {
+				r[1] = mid
+			}
+			even = !even
+		}
+	}
+
+	return GeohashCell{
+		Hash: strings.ToLower(hash),
+		SW:   Point{Latitude: latRange[0], Longitude: lngRange[0]},
+		NE:   Point{Latitude: latRange[1], Longitude: lngRange[1]},
+		Center: Point{
+			Latitude:  (latRange[0] + latRange[1]) / 2,
+			Longitude: (lngRange[0] + lngRange[1]) / 2,
+		},
+	}, nil
+}
+
+// BBox returns the bounds of the cell.
+func (c GeohashCell) BBox() BBox {
+	return BBox{SW: c.SW, NE: c.NE}
+}
+
+func toLowerASCII(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// Neighbors returns the cells adjacent to hash in the order N, NE, E, SE,
+// S, SW, W, NW. Longitude wraps around the antimeridian; cells that would
+// lie beyond a pole are returned as empty strings.
+func Neighbors(hash string) ([8]string, error) {
+	var result [8]string
+
+	cell, err := FromGeohash(hash)
+	if err != nil {
+		return result, err
+	}
+
+	height := cell.NE.Latitude - cell.SW.Latitude
+	width := cell.NE.Longitude - cell.SW.Longitude
+	offsets := [8][2]float64{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+
+	for i, o := range offsets {
+		lat := cell.Center.Latitude + o[0]*height
+		if lat < -90 || lat > 90 {
+			continue
+		}
+
+		lng := NormalizeLongitude(cell.Center.Longitude + o[1]*width)
+		result[i], _ = Point{Latitude: lat, Longitude: lng}.Geohash(len(hash))
+	}
+
+	return result, nil
+}
+
+// GeohashCover returns the sorted geohashes of the given precision whose
+// cells intersect the circle of radiusKm around center.
+func GeohashCover(center Point, radiusKm float64, precision int) ([]string, error) {
+	if radiusKm < 0 {
+		return nil, fmt.Errorf("radius must be non-negative, got %f", radiusKm)
+	}
+
+	origin, err := center.Geohash(precision)
+	if err != nil {
+		return nil, err
+	}
+
+	cell, _ := FromGeohash(origin)
+	height := cell.NE.Latitude - cell.SW.Latitude
+	width := cell.NE.Longitude - cell.SW.Longitude
+
+	box := BBoxFromRadius(center, radiusKm)
+	minLat, maxLat := box.SW.Latitude, box.NE.Latitude
+	minLng, span := box.lngArc()
+	maxLng := minLng + span
+
+	rows := int(math.Floor((maxLat+90)/height)) - int(math.Floor((minLat+90)/height)) + 1
+	cols := int(math.Floor((maxLng+180)/width)) - int(math.Floor((minLng+180)/width)) + 1
+	cols = min(cols, int(math.Round(360/width)))
+	if rows*cols > maxCoverCells {
+		return nil, fmt.Errorf("covering a %.2f km radius at precision %d needs more than %d cells",
+			radiusKm, precision, maxCoverCells)
+	}
+
+	firstRow := math.Floor((minLat + 90) / height)
+	firstCol := math.Floor((minLng + 180) / width)
+
+	seen := make(map[string]struct{})
+	for r := 0; r < rows; r++ {
+		lat := math.Min(-90+(firstRow+float64(r)+0.5)*height, 90-height/2)
+		for c := 0; c < cols; c++ {
+			lng := NormalizeLongitude(-180 + (firstCol+float64(c)+0.5)*width)
+
+			hash, _ := Point{Latitude: lat, Longitude: lng}.Geohash(precision)
+			if _, ok := seen[hash]; ok {
+				continue
+			}
+
+			candidate, _ := FromGeohash(hash)
+			if distanceToBox(center, candidate.SW, candidate.NE) <= radiusKm*(1+1e-9) {
+				seen[hash] = struct{}{}
+			}
+		}
+	}
+
+	result := make([]string, 0, len(seen))
+	for hash := range seen {
+		result = append(result, hash)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// distanceToBox returns the Haversine distance from p to the nearest point
+// of the lat/lng box [sw, ne], which does not cross the antimeridian.
+func distanceToBox(p, sw, ne Point) float64 {
+	if inBBox(p, sw, ne) {
+		return 0
+	}
+
+	best := math.Inf(1)
+
+	// Along a parallel the distance grows with the longitude difference, so
+	// the nearest point of a horizontal edge has the nearest longitude.
+	lng := nearestLongitude(p.Longitude, sw.Longitude, ne.Longitude)
+	for _, lat := range []float64{sw.Latitude, ne.Latitude} {
+		best = math.Min(best, HaversineDistance(p, Point{Latitude: lat, Longitude: lng}))
+	}
+
+	// Meridians are great circles: project p onto each one and clamp the
+	// foot of the perpendicular to the edge.
+	for _, lng := range []float64{sw.Longitude, ne.Longitude} {
+		dLng := ToRadians(p.Longitude - lng)
+		foot := toDegrees(math.Atan2(math.Tan(ToRadians(p.Latitude)), math.Cos(dLng)))
+		if math.Cos(dLng) < 0 {
+			foot = math.Copysign(90, p.Latitude)
+		}
+		lat := math.Max(sw.Latitude, math.Min(ne.Latitude, foot))
+		best = math.Min(best, HaversineDistance(p, Point{Latitude: lat, Longitude: lng}))
+	}
+
+	return best
+}
+
+// nearestLongitude returns the longitude in [lo, hi] closest to lng,
+// measuring around the antimeridian where that is shorter.
+func nearestLongitude(lng, lo, hi float64) float64 {
+	if lng >= lo && lng <= hi {
+		return lng
+	}
+
+	toLo := math.Abs(NormalizeLongitude(lng - lo))
+	toHi := math.Abs(NormalizeLongitude(lng - hi))
+	if toLo < toHi {
+		return lo
+	}
+	return hi
+}
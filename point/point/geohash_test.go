@@ -0,0 +1,236 @@
+package point
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointGeohash(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		point     Point
+		precision int
+		expected  string
+		shouldErr bool
+	}{
+		{"Spain", Point{Latitude: 42.6, Longitude: -5.6}, 5, "ezs42", false},
+		{"Denmark", Point{Latitude: 57.64911, Longitude: 10.40744}, 11, "u4pruydqqvj", false},
+		{"Origin", Point{Latitude: 0, Longitude: 0}, 6, "s00000", false},
+		{"South west corner", Point{Latitude: -90, Longitude: -180}, 4, "0000", false},
+		{"North east corner", Point{Latitude: 90, Longitude: 180}, 4, "zzzz", false},
+		{"Precision too small", Point{}, 0, "", true},
+		{"Precision too large", Point{}, 13, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tt.point.Geohash(tt.precision)
+
+			if tt.shouldErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestFromGeohash(t *testing.T) {
+	t.Parallel()
+
+	cell, err := FromGeohash("ezs42")
+	require.NoError(t, err)
+	require.Equal(t, "ezs42", cell.Hash)
+	require.InDelta(t, 42.5830078125, cell.SW.Latitude, 1e-12)
+	require.InDelta(t, -5.625, cell.SW.Longitude, 1e-12)
+	require.InDelta(t, 42.626953125, cell.NE.Latitude, 1e-12)
+	require.InDelta(t, -5.5810546875, cell.NE.Longitude, 1e-12)
+	require.InDelta(t, 42.60498046875, cell.Center.Latitude, 1e-12)
+	require.InDelta(t, -5.60302734375, cell.Center.Longitude, 1e-12)
+
+	upper, err := FromGeohash("EZS42")
+	require.NoError(t, err)
+	require.Equal(t, cell, upper)
+
+	for _, hash := range []string{"", "ezs4a", "u4pruydqqvjzz", "ezs 2"} {
+		_, err := FromGeohash(hash)
+		require.Error(t, err, hash)
+	}
+}
+
+func TestGeohashRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(9, 10))
+	for _, p := range randomPoints(r, 500) {
+		for precision := MinGeohashPrecision; precision <= MaxGeohashPrecision; precision++ {
+			hash, err := p.Geohash(precision)
+			require.NoError(t, err)
+			require.Len(t, hash, precision)
+
+			cell, err := FromGeohash(hash)
+			require.NoError(t, err)
+			require.True(t, inBBox(p, cell.SW, cell.NE), "%s does not contain %s", hash, p)
+
+			center, err := cell.Center.Geohash(precision)
+			require.NoError(t, err)
+			require.Equal(t, hash, center)
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		hash     string
+		expected [8]string
+	}{
+		{"Interior", "ezs42", [8]string{"ezs48", "ezs49", "ezs43", "ezs41", "ezs40", "ezefp", "ezefr", "ezefx"}},
+		{"Antimeridian", "8", [8]string{"b", "c", "9", "3", "2", "r", "x", "z"}},
+		{"North pole", "zz", [8]string{"", "", "bp", "bn", "zy", "zw", "zx", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := Neighbors(tt.hash)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, err := Neighbors("a")
+	require.Error(t, err)
+}
+
+func TestGeohashCover(t *testing.T) {
+	t.Parallel()
+
+	moscow := Point{Latitude: 55.7558, Longitude: 37.6176}
+
+	tests := []struct {
+		name      string
+		center    Point
+		radiusKm  float64
+		precision int
+	}{
+		{"City", moscow, 20, 5},
+		{"Point radius", moscow, 0, 6},
+		{"Antimeridian", Point{Latitude: -16.5, Longitude: 179.9}, 150, 4},
+		{"Near pole", Point{Latitude: 88, Longitude: 10}, 400, 3},
+		{"Whole world", Point{}, 20100, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := GeohashCover(tt.center, tt.radiusKm, tt.precision)
+			require.NoError(t, err)
+			require.True(t, sort.StringsAreSorted(result))
+
+			origin, err := tt.center.Geohash(tt.precision)
+			require.NoError(t, err)
+			require.Contains(t, result, origin)
+
+			covered := make(map[string]bool, len(result))
+			for _, hash := range result {
+				require.Len(t, hash, tt.precision)
+				covered[hash] = true
+			}
+
+			// Every sampled point inside the circle must fall in a covering
+			// cell, and every covering cell must reach the circle.
+			r := rand.New(rand.NewPCG(11, 12))
+			for i := 0; i < 2000; i++ {
+				p := tt.center.Destination(r.Float64()*360, tt.radiusKm*math.Sqrt(r.Float64()))
+				hash, err := p.Geohash(tt.precision)
+				require.NoError(t, err)
+				require.True(t, covered[hash], "%s at %s is not covered", hash, p)
+			}
+
+			for _, hash := range result {
+				cell, err := FromGeohash(hash)
+				require.NoError(t, err)
+				require.LessOrEqual(t, distanceToBox(tt.center, cell.SW, cell.NE), tt.radiusKm*(1+1e-9))
+			}
+		})
+	}
+
+	world, err := GeohashCover(Point{}, 20100, 1)
+	require.NoError(t, err)
+	require.Len(t, world, 32)
+
+	_, err = GeohashCover(moscow, -1, 5)
+	require.Error(t, err)
+
+	_, err = GeohashCover(moscow, 5, 0)
+	require.Error(t, err)
+
+	_, err = GeohashCover(moscow, 1000, 9)
+	require.Error(t, err)
+}
+
+func TestDistanceToBox(t *testing.T) {
+	t.Parallel()
+
+	sw := Point{Latitude: 50, Longitude: 30}
+	ne := Point{Latitude: 60, Longitude: 40}
+
+	require.Zero(t, distanceToBox(Point{Latitude: 55, Longitude: 35}, sw, ne))
+
+	// Due south of the box the nearest point is straight up the meridian.
+	south := Point{Latitude: 45, Longitude: 35}
+	require.InDelta(t, HaversineDistance(south, Point{Latitude: 50, Longitude: 35}), distanceToBox(south, sw, ne), 1e-9)
+
+	// Due east the nearest point of the western meridian edge lies south of
+	// the parallel through the query, since great circles bow poleward.
+	east := Point{Latitude: 55, Longitude: 60}
+	d := distanceToBox(east, sw, ne)
+	require.Less(t, d, HaversineDistance(east, Point{Latitude: 55, Longitude: 40}))
+
+	r := rand.New(rand.NewPCG(13, 14))
+	for _, q := range randomPoints(r, 200) {
+		if inBBox(q, sw, ne) {
+			continue
+		}
+
+		best := math.Inf(1)
+		for i := 0; i <= 100; i++ {
+			f := float64(i) / 100
+			for _, p := range []Point{
+				{Latitude: 50 + 10*f, Longitude: 30},
+				{Latitude: 50 + 10*f, Longitude: 40},
+				{Latitude: 50, Longitude: 30 + 10*f},
+				{Latitude: 60, Longitude: 30 + 10*f},
+			} {
+				best = math.Min(best, HaversineDistance(q, p))
+			}
+		}
+		result := distanceToBox(q, sw, ne)
+		require.LessOrEqual(t, result, best+1e-9)
+		require.InDelta(t, best, result, 10)
+	}
+}
+
+func TestGeohashCellBBox(t *testing.T) {
+	t.Parallel()
+
+	cell, err := FromGeohash("u4pru")
+	require.NoError(t, err)
+	require.Equal(t, BBox{SW: cell.SW, NE: cell.NE}, cell.BBox())
+	require.True(t, cell.BBox().Contains(cell.Center))
+}
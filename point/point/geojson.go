@@ -0,0 +1,115 @@
+package point
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// GeoJSONType is the type of a GeoJSON geometry holding points.
+type GeoJSONType string
+
+const (
+	GeoJSONPoint      GeoJSONType = "Point"
+	GeoJSONMultiPoint GeoJSONType = "MultiPoint"
+	GeoJSONLineString GeoJSONType = "LineString"
+)
+
+type geoJSONObject struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometry    *geoJSONObject  `json:"geometry,omitempty"`
+}
+
+type geoJSONGeometry struct {
+	Type        GeoJSONType `json:"type"`
+	Coordinates any         `json:"coordinates"`
+}
+
+// ReadGeoJSON decodes a Point, MultiPoint or LineString geometry, bare or
+// wrapped in a Feature. Positions are [longitude, latitude] with an
+// optional altitude, which is ignored.
+func ReadGeoJSON(r io.Reader) (GeoJSONType, []Point, error) {
+	var obj geoJSONObject
+	if err := json.NewDecoder(r).Decode(&obj); err != nil {
+		return "", nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+
+	if obj.Type == "Feature" {
+		if obj.Geometry == nil {
+			return "", nil, fmt.Errorf("GeoJSON feature has no geometry")
+		}
+		obj = *obj.Geometry
+	}
+
+	typ := GeoJSONType(obj.Type)
+	switch typ {
+	case GeoJSONPoint:
+		var position []float64
+		if err := json.Unmarshal(obj.Coordinates, &position); err != nil {
+			return "", nil, fmt.Errorf("invalid GeoJSON %s coordinates: %w", typ, err)
+		}
+
+		p, err := fromPosition(position)
+		if err != nil {
+			return "", nil, err
+		}
+		return typ, []Point{p}, nil
+
+	case GeoJSONMultiPoint, GeoJSONLineString:
+		var positions [][]float64
+		if err := json.Unmarshal(obj.Coordinates, &positions); err != nil {
+			return "", nil, fmt.Errorf("invalid GeoJSON %s coordinates: %w", typ, err)
+		}
+		if typ == GeoJSONLineString && len(positions) < 2 {
+			return "", nil, fmt.Errorf("GeoJSON LineString needs at least 2 positions, got %d", len(positions))
+		}
+
+		points := make([]Point, len(positions))
+		for i, position := range positions {
+			p, err := fromPosition(position)
+			if err != nil {
+				return "", nil, fmt.Errorf("position %d: %w", i, err)
+			}
+			points[i] = p
+		}
+		return typ, points, nil
+
+	default:
+		return "", nil, fmt.Errorf("unsupported GeoJSON type %q, expected Point, MultiPoint or LineString", obj.Type)
+	}
+}
+
+func fromPosition(position []float64) (Point, error) {
+	if len(position) < 2 || len(position) > 3 {
+		return Point{}, fmt.Errorf("GeoJSON position must have 2 or 3 values, got %d", len(position))
+	}
+	return New(position[1], position[0])
+}
+
+// WriteGeoJSON encodes points as a geometry of the given type. A Point
+// takes exactly one point and a LineString at least two.
+func WriteGeoJSON(w io.Writer, typ GeoJSONType, points []Point) error {
+	positions := make([][2]float64, len(points))
+	for i, p := range points {
+		positions[i] = [2]float64{p.Longitude, p.Latitude}
+	}
+
+	geometry := geoJSONGeometry{Type: typ, Coordinates: positions}
+	switch typ {
+	case GeoJSONPoint:
+		if len(points) != 1 {
+			return fmt.Errorf("GeoJSON Point needs exactly 1 point, got %d", len(points))
+		}
+		geometry.Coordinates = positions[0]
+	case GeoJSONMultiPoint:
+	case GeoJSONLineString:
+		if len(points) < 2 {
+			return fmt.Errorf("GeoJSON LineString needs at least 2 points, got %d", len(points))
+		}
+	default:
+		return fmt.Errorf("unsupported GeoJSON type %q, expected Point, MultiPoint or LineString", typ)
+	}
+
+	return json.NewEncoder(w).Encode(geometry)
+}
@@ -0,0 +1,123 @@
+package point
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadGeoJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		typ       GeoJSONType
+		expected  []Point
+		shouldErr bool
+	}{
+		{
+			"Point",
+			`{"type": "Point", "coordinates": [37.6176, 55.7558]}`,
+			GeoJSONPoint,
+			[]Point{{Latitude: 55.7558, Longitude: 37.6176}},
+			false,
+		},
+		{
+			"Point with altitude",
+			`{"type": "Point", "coordinates": [37.6176, 55.7558, 150]}`,
+			GeoJSONPoint,
+			[]Point{{Latitude: 55.7558, Longitude: 37.6176}},
+			false,
+		},
+		{
+			"MultiPoint",
+			`{"type": "MultiPoint", "coordinates": [[37.6176, 55.7558], [30.3609, 59.9311]]}`,
+			GeoJSONMultiPoint,
+			[]Point{{Latitude: 55.7558, Longitude: 37.6176}, {Latitude: 59.9311, Longitude: 30.3609}},
+			false,
+		},
+		{
+			"LineString feature",
+			`{"type": "Feature", "properties": {"name": "M11"}, "geometry": {"type": "LineString", "coordinates": [[37.6176, 55.7558], [30.3609, 59.9311]]}}`,
+			GeoJSONLineString,
+			[]Point{{Latitude: 55.7558, Longitude: 37.6176}, {Latitude: 59.9311, Longitude: 30.3609}},
+			false,
+		},
+		{"Empty MultiPoint", `{"type": "MultiPoint", "coordinates": []}`, GeoJSONMultiPoint, []Point{}, false},
+		{"Short LineString", `{"type": "LineString", "coordinates": [[37.6176, 55.7558]]}`, "", nil, true},
+		{"Unsupported type", `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}`, "", nil, true},
+		{"Feature without geometry", `{"type": "Feature", "geometry": null}`, "", nil, true},
+		{"Short position", `{"type": "Point", "coordinates": [37.6176]}`, "", nil, true},
+		{"Latitude out of range", `{"type": "MultiPoint", "coordinates": [[0, 0], [0, 91]]}`, "", nil, true},
+		{"Wrong nesting", `{"type": "MultiPoint", "coordinates": [37.6176, 55.7558]}`, "", nil, true},
+		{"Malformed", `{"type": "Point",`, "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			typ, points, err := ReadGeoJSON(strings.NewReader(tt.input))
+
+			if tt.shouldErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.typ, typ)
+			require.Equal(t, tt.expected, points)
+		})
+	}
+}
+
+func TestWriteGeoJSON(t *testing.T) {
+	t.Parallel()
+
+	moscow := Point{Latitude: 55.7558, Longitude: 37.6176}
+	spb := Point{Latitude: 59.9311, Longitude: 30.3609}
+
+	tests := []struct {
+		name      string
+		typ       GeoJSONType
+		points    []Point
+		expected  string
+		shouldErr bool
+	}{
+		{"Point", GeoJSONPoint, []Point{moscow}, `{"type":"Point","coordinates":[37.6176,55.7558]}`, false},
+		{"MultiPoint", GeoJSONMultiPoint, []Point{moscow, spb}, `{"type":"MultiPoint","coordinates":[[37.6176,55.7558],[30.3609,59.9311]]}`, false},
+		{"Empty MultiPoint", GeoJSONMultiPoint, nil, `{"type":"MultiPoint","coordinates":[]}`, false},
+		{"LineString", GeoJSONLineString, []Point{moscow, spb}, `{"type":"LineString","coordinates":[[37.6176,55.7558],[30.3609,59.9311]]}`, false},
+		{"Point with two points", GeoJSONPoint, []Point{moscow, spb}, "", true},
+		{"Short LineString", GeoJSONLineString, []Point{moscow}, "", true},
+		{"Unsupported type", "Polygon", []Point{moscow}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			err := WriteGeoJSON(&buf, tt.typ, tt.points)
+
+			if tt.shouldErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expected, buf.String())
+
+			typ, points, err := ReadGeoJSON(&buf)
+			require.NoError(t, err)
+			require.Equal(t, tt.typ, typ)
+			require.Len(t, points, len(tt.points))
+			for i, p := range tt.points {
+				require.Equal(t, p, points[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package point
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+type gpxFile struct {
+	XMLName xml.Name   `xml:"gpx"`
+	Version string     `xml:"version,attr"`
+	Creator string     `xml:"creator,attr"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	Tracks  []gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+type gpxPoint struct {
+	Lat float64 `xml:"lat,attr"`
+	Lon float64 `xml:"lon,attr"`
+}
+
+// ReadGPX returns the track points of a GPX 1.1 document in order, joining
+// all tracks and segments into one route.
+func ReadGPX(r io.Reader) ([]Point, error) {
+	var doc gpxFile
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid GPX: %w", err)
+	}
+
+	var points []Point
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			for _, pt := range seg.Points {
+				p, err := New(pt.Lat, pt.Lon)
+				if err != nil {
+					return nil, fmt.Errorf("track point %d: %w", len(points), err)
+				}
+				points = append(points, p)
+			}
+		}
+	}
+
+	return points, nil
+}
+
+// WriteGPX encodes points as a single-segment GPX 1.1 track.
+func WriteGPX(w io.Writer, points []Point) error {
+	seg := gpxSegment{Points: make([]gpxPoint, len(points))}
+	for i, p := range points {
+		seg.Points[i] = gpxPoint{Lat: p.Latitude, Lon: p.Longitude}
+	}
+
+	doc := gpxFile{
+		Version: "1.1",
+		Creator: "point",
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+		Tracks:  []gpxTrack{{Segments: []gpxSegment{seg}}},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,83 @@
+package point
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadGPX(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		expected  []Point
+		shouldErr bool
+	}{
+		{
+			"Track with two segments",
+			`<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <wpt lat="0" lon="0"><name>ignored</name></wpt>
+  <trk>
+    <name>Morning ride</name>
+    <trkseg>
+      <trkpt lat="55.7558" lon="37.6176"><ele>150</ele><time>2024-05-01T06:00:00Z</time></trkpt>
+      <trkpt lat="55.76" lon="37.62"/>
+    </trkseg>
+    <trkseg>
+      <trkpt lat="55.77" lon="37.63"/>
+    </trkseg>
+  </trk>
+</gpx>`,
+			[]Point{
+				{Latitude: 55.7558, Longitude: 37.6176},
+				{Latitude: 55.76, Longitude: 37.62},
+				{Latitude: 55.77, Longitude: 37.63},
+			},
+			false,
+		},
+		{"No tracks", `<gpx version="1.1"></gpx>`, nil, false},
+		{"Out of range", `<gpx><trk><trkseg><trkpt lat="95" lon="0"/></trkseg></trk></gpx>`, nil, true},
+		{"Invalid number", `<gpx><trk><trkseg><trkpt lat="north" lon="0"/></trkseg></trk></gpx>`, nil, true},
+		{"Malformed", `<gpx><trk>`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			points, err := ReadGPX(strings.NewReader(tt.input))
+
+			if tt.shouldErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, points)
+		})
+	}
+}
+
+func TestWriteGPXRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	route := []Point{
+		{Latitude: 55.7558, Longitude: 37.6176},
+		{Latitude: 59.9311, Longitude: 30.3609},
+		{Latitude: -33.8688, Longitude: 151.2093},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteGPX(&buf, route))
+	require.True(t, strings.HasPrefix(buf.String(), `<?xml version="1.0" encoding="UTF-8"?>`))
+	require.Contains(t, buf.String(), `<trkpt lat="55.7558" lon="37.6176"></trkpt>`)
+
+	points, err := ReadGPX(&buf)
+	require.NoError(t, err)
+	require.Equal(t, route, points)
+}
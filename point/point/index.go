@@ -0,0 +1,427 @@
+package point
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// Index is a spatial index over keyed points supporting nearest-neighbour,
+// radius and bounding box queries. Points are stored as unit vectors in a
+// k-d tree, so queries behave the same across the antimeridian and near
+// the poles. The zero value is not usable; create one with NewIndex.
+type Index[K comparable] struct {
+	root    *kdNode[K]
+	nodes   map[K]*kdNode[K]
+	deleted int
+	seq     uint64
+}
+
+// Entry is a keyed point stored in an Index.
+type Entry[K comparable] struct {
+	Key   K
+	Point Point
+}
+
+// Neighbor is an Entry together with its distance to a query point.
+type Neighbor[K comparable] struct {
+	Entry[K]
+	DistanceKm float64
+}
+
+type vec3 [3]float64
+
+type kdNode[K comparable] struct {
+	entry       Entry[K]
+	v           vec3
+	seq         uint64
+	axis        int
+	deleted     bool
+	size        int
+	left, right *kdNode[K]
+}
+
+// A subtree is rebuilt when one of its children holds more than this share
+// of its nodes, as in a scapegoat tree.
+const kdBalance = 0.7
+
+func NewIndex[K comparable]() *Index[K] {
+	return &Index[K]{
+		nodes: make(map[K]*kdNode[K]),
+	}
+}
+
+// Len returns the number of points in the index.
+func (idx *Index[K]) Len() int {
+	return len(idx.nodes)
+}
+
+// Insert adds p under key, replacing any point already stored for key.
+func (idx *Index[K]) Insert(key K, p Point) {
+	idx.Delete(key)
+
+	idx.seq++
+	node := &kdNode[K]{entry: Entry[K]{Key: key, Point: p}, v: toVec3(p), seq: idx.seq, size: 1}
+	idx.nodes[key] = node
+
+	if idx.root == nil {
+		idx.root = node
+		return
+	}
+
+	var path []*kdNode[K]
+	for cur := idx.root; ; {
+		path = append(path, cur)
+		cur.size++
+		node.axis = (cur.axis + 1) % 3
+
+		if node.less(cur, cur.axis) {
+			if cur.left == nil {
+				cur.left = node
+				break
+			}
+			cur = cur.left
+		} else {
+			if cur.right == nil {
+				cur.right = node
+				break
+			}
+			cur = cur.right
+		}
+	}
+
+	maxDepth := math.Log(float64(idx.root.size))/math.Log(1/kdBalance) + 1
+	if float64(len(path)) <= maxDepth {
+		return
+	}
+
+	// Rebuild the highest unbalanced subtree on the insertion path.
+	for i, n := range path {
+		if float64(max(subtreeSize(n.left), subtreeSize(n.right))) > kdBalance*float64(n.size) {
+			idx.rebuildSubtree(path[:i], n)
+			return
+		}
+	}
+}
+
+// Delete removes the point stored under key and reports whether it was
+// present.
+func (idx *Index[K]) Delete(key K) bool {
+	node, ok := idx.nodes[key]
+	if !ok {
+		return false
+	}
+
+	node.deleted = true
+	delete(idx.nodes, key)
+	idx.deleted++
+
+	if idx.deleted > len(idx.nodes) {
+		idx.rebuild()
+	}
+
+	return true
+}
+
+// Get returns the point stored under key.
+func (idx *Index[K]) Get(key K) (Point, bool) {
+	node, ok := idx.nodes[key]
+	if !ok {
+		return Point{}, false
+	}
+	return node.entry.Point, true
+}
+
+// Nearest returns up to k entries closest to p, nearest first.
+func (idx *Index[K]) Nearest(p Point, k int) []Neighbor[K] {
+	if k <= 0 || idx.root == nil {
+		return nil
+	}
+
+	q := toVec3(p)
+	h := &neighborHeap[K]{}
+	var search func(n *kdNode[K])
+	search = func(n *kdNode[K]) {
+		if n == nil {
+			return
+		}
+
+		if !n.deleted {
+			d := chordSq(q, n.v)
+			if h.Len() < k {
+				heap.Push(h, kdCandidate[K]{node: n, chordSq: d})
+			} else if d < (*h)[0].chordSq {
+				(*h)[0] = kdCandidate[K]{node: n, chordSq: d}
+				heap.Fix(h, 0)
+			}
+		}
+
+		diff := q[n.axis] - n.v[n.axis]
+		near, far := n.left, n.right
+		if diff >= 0 {
+			near, far = far, near
+		}
+
+		search(near)
+		if h.Len() < k || diff*diff < (*h)[0].chordSq {
+			search(far)
+		}
+	}
+	search(idx.root)
+
+	result := make([]Neighbor[K], h.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		c := heap.Pop(h).(kdCandidate[K])
+		result[i] = Neighbor[K]{Entry: c.node.entry, DistanceKm: HaversineDistance(p, c.node.entry.Point)}
+	}
+
+	return result
+}
+
+// WithinRadius returns the entries within radiusKm of p by Haversine
+// distance, nearest first. It agrees with Point.IsWithinRadius.
+func (idx *Index[K]) WithinRadius(p Point, radiusKm float64) []Neighbor[K] {
+	if radiusKm < 0 || idx.root == nil {
+		return nil
+	}
+
+	// The chord bound only prunes the search; a slightly larger bound keeps
+	// rounding from dropping points on the boundary.
+	angle := math.Min(radiusKm/earthRadiusKm, math.Pi)
+	chord := 2*math.Sin(angle/2)*(1+1e-9) + 1e-12
+
+	q := toVec3(p)
+	var result []Neighbor[K]
+	var search func(n *kdNode[K])
+	search = func(n *kdNode[K]) {
+		if n == nil {
+			return
+		}
+
+		if !n.deleted && chordSq(q, n.v) <= chord*chord {
+			if d := HaversineDistance(p, n.entry.Point); d <= radiusKm {
+				result = append(result, Neighbor[K]{Entry: n.entry, DistanceKm: d})
+			}
+		}
+
+		diff := q[n.axis] - n.v[n.axis]
+		if diff > -chord {
+			search(n.right)
+		}
+		if diff < chord {
+			search(n.left)
+		}
+	}
+	search(idx.root)
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DistanceKm < result[j].DistanceKm
+	})
+
+	return result
+}
+
+// WithinBBox returns the entries inside the box spanned by its south-west
+// and north-east corners. If sw is east of ne the box crosses the
+// antimeridian.
+func (idx *Index[K]) WithinBBox(sw, ne Point) []Entry[K] {
+	return idx.WithinBox(BBox{SW: sw, NE: ne})
+}
+
+// WithinBox returns the entries inside the box.
+func (idx *Index[K]) WithinBox(b BBox) []Entry[K] {
+	if idx.root == nil || b.SW.Latitude > b.NE.Latitude {
+		return nil
+	}
+
+	lo, hi := bboxBounds(b.SW, b.NE)
+
+	var result []Entry[K]
+	var search func(n *kdNode[K])
+	search = func(n *kdNode[K]) {
+		if n == nil {
+			return
+		}
+
+		if !n.deleted && b.Contains(n.entry.Point) {
+			result = append(result, n.entry)
+		}
+
+		if n.v[n.axis] <= hi[n.axis] {
+			search(n.right)
+		}
+		if n.v[n.axis] >= lo[n.axis] {
+			search(n.left)
+		}
+	}
+	search(idx.root)
+
+	return result
+}
+
+func inBBox(p, sw, ne Point) bool {
+	if p.Latitude < sw.Latitude || p.Latitude > ne.Latitude {
+		return false
+	}
+	if sw.Longitude <= ne.Longitude {
+		return p.Longitude >= sw.Longitude && p.Longitude <= ne.Longitude
+	}
+	return p.Longitude >= sw.Longitude || p.Longitude <= ne.Longitude
+}
+
+// bboxBounds returns the axis-aligned box enclosing the unit vectors of
+// all points in the lat/lng box. The extremes of x and y lie on the box
+// edges, at the equator or where the longitude crosses a multiple of 90°.
+func bboxBounds(sw, ne Point) (vec3, vec3) {
+	lats := []float64{sw.Latitude, ne.Latitude}
+	if sw.Latitude < 0 && ne.Latitude > 0 {
+		lats = append(lats, 0)
+	}
+
+	lngs := []float64{sw.Longitude, ne.Longitude}
+	for lng := -180.0; lng <= 180; lng += 90 {
+		if inBBox(Point{Latitude: sw.Latitude, Longitude: lng}, sw, ne) {
+			lngs = append(lngs, lng)
+		}
+	}
+
+	lo := vec3{math.Inf(1), math.Inf(1), math.Inf(1)}
+	hi := vec3{math.Inf(-1), math.Inf(-1), math.Inf(-1)}
+	for _, lat := range lats {
+		for _, lng := range lngs {
+			v := toVec3(Point{Latitude: lat, Longitude: lng})
+			for i := range v {
+				lo[i] = math.Min(lo[i], v[i])
+				hi[i] = math.Max(hi[i], v[i])
+			}
+		}
+	}
+
+	// Pad for rounding in the trigonometry.
+	for i := range lo {
+		lo[i] -= 1e-12
+		hi[i] += 1e-12
+	}
+
+	return lo, hi
+}
+
+func (idx *Index[K]) rebuild() {
+	nodes := make([]*kdNode[K], 0, len(idx.nodes))
+	for _, n := range idx.nodes {
+		nodes = append(nodes, n)
+	}
+
+	idx.root = build(nodes, 0)
+	idx.deleted = 0
+}
+
+// rebuildSubtree replaces the subtree rooted at n, whose ancestors are
+// path, with a balanced tree of its live nodes.
+func (idx *Index[K]) rebuildSubtree(path []*kdNode[K], n *kdNode[K]) {
+	var nodes []*kdNode[K]
+	var collect func(c *kdNode[K])
+	collect = func(c *kdNode[K]) {
+		if c == nil {
+			return
+		}
+		if !c.deleted {
+			nodes = append(nodes, c)
+		}
+		collect(c.left)
+		collect(c.right)
+	}
+	collect(n)
+
+	dropped := n.size - len(nodes)
+	idx.deleted -= dropped
+	for _, ancestor := range path {
+		ancestor.size -= dropped
+	}
+
+	subtree := build(nodes, n.axis)
+	switch {
+	case len(path) == 0:
+		idx.root = subtree
+	case path[len(path)-1].left == n:
+		path[len(path)-1].left = subtree
+	default:
+		path[len(path)-1].right = subtree
+	}
+}
+
+func subtreeSize[K comparable](n *kdNode[K]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func build[K comparable](nodes []*kdNode[K], axis int) *kdNode[K] {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].less(nodes[j], axis)
+	})
+
+	m := len(nodes) / 2
+	n := nodes[m]
+	n.axis = axis
+	n.size = len(nodes)
+	n.left = build(nodes[:m], (axis+1)%3)
+	n.right = build(nodes[m+1:], (axis+1)%3)
+
+	return n
+}
+
+// less orders nodes along axis, breaking ties by insertion order so that
+// points sharing a coordinate split evenly between the subtrees instead of
+// piling up on one side. The queries search both sides of a splitting
+// plane they touch, so they find equal coordinates on either.
+func (n *kdNode[K]) less(o *kdNode[K], axis int) bool {
+	if n.v[axis] != o.v[axis] {
+		return n.v[axis] < o.v[axis]
+	}
+	return n.seq < o.seq
+}
+
+func toVec3(p Point) vec3 {
+	lat, lng := ToRadians(p.Latitude), ToRadians(p.Longitude)
+	return vec3{
+		math.Cos(lat) * math.Cos(lng),
+		math.Cos(lat) * math.Sin(lng),
+		math.Sin(lat),
+	}
+}
+
+func chordSq(a, b vec3) float64 {
+	dx, dy, dz := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return dx*dx + dy*dy + dz*dz
+}
+
+type kdCandidate[K comparable] struct {
+	node    *kdNode[K]
+	chordSq float64
+}
+
+// neighborHeap is a max-heap on chord distance holding the best k
+// candidates found so far.
+type neighborHeap[K comparable] []kdCandidate[K]
+
+func (h neighborHeap[K]) Len() int           { return len(h) }
+func (h neighborHeap[K]) Less(i, j int) bool { return h[i].chordSq > h[j].chordSq }
+func (h neighborHeap[K]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *neighborHeap[K]) Push(x any) {
+	*h = append(*h, x.(kdCandidate[K]))
+}
+
+func (h *neighborHeap[K]) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,339 @@
+package point
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomPoints(r *rand.Rand, n int) []Point {
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{
+			Latitude:  r.Float64()*180 - 90,
+			Longitude: r.Float64()*360 - 180,
+		}
+	}
+	return points
+}
+
+func newTestIndex(points []Point) *Index[int] {
+	idx := NewIndex[int]()
+	for i, p := range points {
+		idx.Insert(i, p)
+	}
+	return idx
+}
+
+func sortedKeys(entries []Entry[int]) []int {
+	result := make([]int, len(entries))
+	for i, e := range entries {
+		result[i] = e.Key
+	}
+	sort.Ints(result)
+	return result
+}
+
+func neighborKeys(neighbors []Neighbor[int]) []int {
+	result := make([]int, len(neighbors))
+	for i, n := range neighbors {
+		result[i] = n.Key
+	}
+	return result
+}
+
+func TestIndexInsertGetDelete(t *testing.T) {
+	t.Parallel()
+
+	idx := NewIndex[string]()
+	require.Zero(t, idx.Len())
+	require.Empty(t, idx.Nearest(Point{}, 3))
+
+	moscow := Point{Latitude: 55.7558, Longitude: 37.6176}
+	spb := Point{Latitude: 59.9311, Longitude: 30.3609}
+
+	idx.Insert("moscow", moscow)
+	idx.Insert("spb", spb)
+	require.Equal(t, 2, idx.Len())
+
+	p, ok := idx.Get("moscow")
+	require.True(t, ok)
+	require.Equal(t, moscow, p)
+
+	idx.Insert("moscow", spb)
+	require.Equal(t, 2, idx.Len())
+	p, ok = idx.Get("moscow")
+	require.True(t, ok)
+	require.Equal(t, spb, p)
+
+	require.True(t, idx.Delete("moscow"))
+	require.False(t, idx.Delete("moscow"))
+	require.Equal(t, 1, idx.Len())
+
+	_, ok = idx.Get("moscow")
+	require.False(t, ok)
+
+	nearest := idx.Nearest(moscow, 5)
+	require.Len(t, nearest, 1)
+	require.Equal(t, "spb", nearest[0].Key)
+}
+
+func TestIndexNearest(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(1, 2))
+	points := randomPoints(r, 2000)
+	idx := newTestIndex(points)
+
+	for _, q := range randomPoints(r, 50) {
+		distances := make([]float64, len(points))
+		for i, p := range points {
+			distances[i] = HaversineDistance(q, p)
+		}
+		sort.Float64s(distances)
+
+		for _, k := range []int{1, 5, 20} {
+			result := idx.Nearest(q, k)
+			require.Len(t, result, k)
+
+			for i, n := range result {
+				require.InDelta(t, distances[i], n.DistanceKm, 1e-9)
+				require.Equal(t, points[n.Key], n.Point)
+			}
+		}
+	}
+
+	require.Len(t, idx.Nearest(Point{}, 5000), 2000)
+	require.Empty(t, idx.Nearest(Point{}, 0))
+}
+
+func TestIndexWithinRadius(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(3, 4))
+	points := randomPoints(r, 3000)
+	idx := newTestIndex(points)
+
+	queries := append(randomPoints(r, 30),
+		Point{Latitude: 0, Longitude: 180},
+		Point{Latitude: 89.9, Longitude: 0},
+		Point{Latitude: -90, Longitude: 0},
+	)
+
+	for _, q := range queries {
+		for _, radius := range []float64{0, 100, 1000, 5000, 20100} {
+			var expected []int
+			for i, p := range points {
+				if p.IsWithinRadius(q, radius) {
+					expected = append(expected, i)
+				}
+			}
+
+			result := idx.WithinRadius(q, radius)
+			actual := neighborKeys(result)
+			sort.Ints(actual)
+			require.Equal(t, len(expected), len(actual))
+			if len(expected) > 0 {
+				require.Equal(t, expected, actual)
+			}
+
+			for i := 1; i < len(result); i++ {
+				require.LessOrEqual(t, result[i-1].DistanceKm, result[i].DistanceKm)
+			}
+		}
+	}
+
+	require.Empty(t, idx.WithinRadius(Point{}, -1))
+}
+
+func TestIndexWithinRadiusBoundary(t *testing.T) {
+	t.Parallel()
+
+	moscow := Point{Latitude: 55.7558, Longitude: 37.6176}
+	spb := Point{Latitude: 59.9311, Longitude: 30.3609}
+
+	idx := NewIndex[string]()
+	idx.Insert("spb", spb)
+
+	radius := HaversineDistance(moscow, spb)
+	require.Len(t, idx.WithinRadius(moscow, radius), 1)
+	require.Empty(t, idx.WithinRadius(moscow, radius*(1-1e-9)))
+}
+
+func TestIndexWithinBBox(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(5, 6))
+	points := randomPoints(r, 3000)
+	idx := newTestIndex(points)
+
+	tests := []struct {
+		name string
+		sw   Point
+		ne   Point
+	}{
+		{"Small box", Point{Latitude: 50, Longitude: 30}, Point{Latitude: 60, Longitude: 40}},
+		{"Straddles equator and prime meridian", Point{Latitude: -20, Longitude: -20}, Point{Latitude: 20, Longitude: 20}},
+		{"Crosses antimeridian", Point{Latitude: -30, Longitude: 170}, Point{Latitude: 30, Longitude: -170}},
+		{"Polar cap", Point{Latitude: 70, Longitude: -180}, Point{Latitude: 90, Longitude: 180}},
+		{"Whole world", Point{Latitude: -90, Longitude: -180}, Point{Latitude: 90, Longitude: 180}},
+		{"Empty latitude range", Point{Latitude: 10, Longitude: 0}, Point{Latitude: -10, Longitude: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var expected []int
+			for i, p := range points {
+				if tt.sw.Latitude <= tt.ne.Latitude && inBBox(p, tt.sw, tt.ne) {
+					expected = append(expected, i)
+				}
+			}
+
+			actual := sortedKeys(idx.WithinBBox(tt.sw, tt.ne))
+			require.Equal(t, len(expected), len(actual))
+			if len(expected) > 0 {
+				require.Equal(t, expected, actual)
+			}
+			require.Equal(t, actual, sortedKeys(idx.WithinBox(BBox{SW: tt.sw, NE: tt.ne})))
+		})
+	}
+}
+
+func TestIndexIncrementalUpdates(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(7, 8))
+	idx := NewIndex[int]()
+	live := map[int]Point{}
+
+	for step := 0; step < 5000; step++ {
+		key := r.IntN(1000)
+		if r.IntN(3) == 0 {
+			_, present := live[key]
+			require.Equal(t, present, idx.Delete(key))
+			delete(live, key)
+			continue
+		}
+
+		p := randomPoints(r, 1)[0]
+		idx.Insert(key, p)
+		live[key] = p
+	}
+
+	require.Equal(t, len(live), idx.Len())
+
+	q := Point{Latitude: 10, Longitude: 10}
+	var expected []int
+	for key, p := range live {
+		if p.IsWithinRadius(q, 3000) {
+			expected = append(expected, key)
+		}
+	}
+	sort.Ints(expected)
+
+	actual := neighborKeys(idx.WithinRadius(q, 3000))
+	sort.Ints(actual)
+	require.Equal(t, expected, actual)
+}
+
+func TestIndexSortedInsertStaysBalanced(t *testing.T) {
+	t.Parallel()
+
+	idx := NewIndex[int]()
+	for i := 0; i < 10000; i++ {
+		idx.Insert(i, Point{Latitude: 0, Longitude: -180 + float64(i)*0.03})
+	}
+
+	require.Less(t, kdDepth(idx.root), 60)
+}
+
+func kdDepth(n *kdNode[int]) int {
+	if n == nil {
+		return 0
+	}
+	return 1 + max(kdDepth(n.left), kdDepth(n.right))
+}
+
+func TestIndexDuplicatePointsStayBalanced(t *testing.T) {
+	t.Parallel()
+
+	p := Point{Latitude: 52.52, Longitude: 13.405}
+	idx := NewIndex[int]()
+	for i := 0; i < 5000; i++ {
+		idx.Insert(i, p)
+	}
+	require.Less(t, kdDepth(idx.root), 40)
+
+	// Rebuilding after deletes splits the duplicates evenly too.
+	for i := 0; i < 3000; i++ {
+		idx.Delete(i)
+	}
+	require.Less(t, kdDepth(idx.root), 40)
+
+	require.Len(t, idx.Nearest(p, 10), 10)
+	require.Len(t, idx.WithinRadius(p, 1), 2000)
+	require.Len(t, idx.WithinBox(BBox{SW: Point{Latitude: 52, Longitude: 13}, NE: Point{Latitude: 53, Longitude: 14}}), 2000)
+}
+
+func BenchmarkIndexInsert(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 1))
+	points := randomPoints(r, 100000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newTestIndex(points)
+	}
+}
+
+func BenchmarkIndexNearest(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 1))
+	idx := newTestIndex(randomPoints(r, 100000))
+	queries := randomPoints(r, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.Nearest(queries[i%len(queries)], 10)
+	}
+}
+
+func BenchmarkIndexWithinRadius(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 1))
+	idx := newTestIndex(randomPoints(r, 100000))
+	queries := randomPoints(r, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.WithinRadius(queries[i%len(queries)], 100)
+	}
+}
+
+func BenchmarkLinearWithinRadius(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 1))
+	points := randomPoints(r, 100000)
+	queries := randomPoints(r, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := queries[i%len(queries)]
+		var result []Point
+		for _, p := range points {
+			if p.IsWithinRadius(q, 100) {
+				result = append(result, p)
+			}
+		}
+	}
+}
+
+func BenchmarkIndexWithinBBox(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 1))
+	idx := newTestIndex(randomPoints(r, 100000))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.WithinBBox(Point{Latitude: 50, Longitude: 30}, Point{Latitude: 51, Longitude: 31})
+	}
+}
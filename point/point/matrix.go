@@ -0,0 +1,73 @@
+package point
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// DistanceFunc returns the distance between two points in km. It must be
+// symmetric and safe for concurrent use.
+type DistanceFunc func(p1, p2 Point) float64
+
+// MatrixOptions configures DistanceMatrix.
+type MatrixOptions struct {
+	// Workers is the number of goroutines computing rows. Zero means
+	// runtime.GOMAXPROCS(0).
+	Workers int
+	// Distance measures each pair. Nil means HaversineDistance; use
+	// VincentyDistance for distances on the WGS-84 ellipsoid.
+	Distance DistanceFunc
+}
+
+// DistanceMatrix returns the symmetric matrix of distances between every
+// pair of points, with zeros on the diagonal. Each pair is measured once;
+// rows are handed out to the workers as they become free.
+func DistanceMatrix(points []Point, opts MatrixOptions) ([][]float64, error) {
+	if opts.Workers < 0 {
+		return nil, fmt.Errorf("number of workers must not be negative, got %d", opts.Workers)
+	}
+
+	workers := opts.Workers
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	distance := opts.Distance
+	if distance == nil {
+		distance = HaversineDistance
+	}
+
+	n := len(points)
+	cells := make([]float64, n*n)
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = cells[i*n : (i+1)*n : (i+1)*n]
+	}
+
+	rows := make(chan int, n)
+	for i := 0; i < n; i++ {
+		rows <- i
+	}
+	close(rows)
+
+	// Row i fills the cells right of the diagonal and their mirror images
+	// below it, so no cell is written by two workers.
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rows {
+				for j := i + 1; j < n; j++ {
+					d := distance(points[i], points[j])
+					matrix[i][j] = d
+					matrix[j][i] = d
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return matrix, nil
+}
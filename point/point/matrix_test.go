@@ -0,0 +1,91 @@
+package point
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistanceMatrix(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(19, 20))
+	points := randomPoints(r, 120)
+
+	tests := []struct {
+		name     string
+		opts     MatrixOptions
+		distance DistanceFunc
+	}{
+		{"Default", MatrixOptions{}, HaversineDistance},
+		{"Single worker", MatrixOptions{Workers: 1}, HaversineDistance},
+		{"More workers than points", MatrixOptions{Workers: 500}, HaversineDistance},
+		{"Ellipsoidal", MatrixOptions{Workers: 4, Distance: VincentyDistance}, VincentyDistance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matrix, err := DistanceMatrix(points, tt.opts)
+			require.NoError(t, err)
+			require.Len(t, matrix, len(points))
+
+			for i := range points {
+				require.Len(t, matrix[i], len(points))
+				require.Zero(t, matrix[i][i])
+				for j := i + 1; j < len(points); j++ {
+					require.Equal(t, tt.distance(points[i], points[j]), matrix[i][j])
+					require.Equal(t, matrix[i][j], matrix[j][i])
+				}
+			}
+		})
+	}
+}
+
+func TestDistanceMatrixMeasuresEachPairOnce(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(21, 22))
+	points := randomPoints(r, 50)
+
+	var calls atomic.Int64
+	_, err := DistanceMatrix(points, MatrixOptions{
+		Workers: 8,
+		Distance: func(p1, p2 Point) float64 {
+			calls.Add(1)
+			return HaversineDistance(p1, p2)
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(50*49/2), calls.Load())
+}
+
+func TestDistanceMatrixEdgeCases(t *testing.T) {
+	t.Parallel()
+
+	matrix, err := DistanceMatrix(nil, MatrixOptions{})
+	require.NoError(t, err)
+	require.Empty(t, matrix)
+
+	matrix, err = DistanceMatrix([]Point{{Latitude: 1, Longitude: 2}}, MatrixOptions{})
+	require.NoError(t, err)
+	require.Equal(t, [][]float64{{0}}, matrix)
+
+	_, err = DistanceMatrix(nil, MatrixOptions{Workers: -1})
+	require.Error(t, err)
+}
+
+func BenchmarkDistanceMatrix(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 1))
+	points := randomPoints(r, 500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DistanceMatrix(points, MatrixOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
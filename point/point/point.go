@@ -3,8 +3,6 @@ package point
 import (
 	"fmt"
 	"math"
-	"strconv"
-	"strings"
 )
 
 const earthRadiusKm = 6371
@@ -46,10 +44,10 @@ func (p Point) IsWithinRadius(center Point, radiusKm float64) bool {
 }
 
 func HaversineDistance(p1, p2 Point) float64 {
-	lat1Rad := toRadians(p1.Latitude)
-	lat2Rad := toRadians(p2.Latitude)
-	deltaLatRad := toRadians(p2.Latitude - p1.Latitude)
-	deltaLngRad := toRadians(p2.Longitude - p1.Longitude)
+	lat1Rad := ToRadians(p1.Latitude)
+	lat2Rad := ToRadians(p2.Latitude)
+	deltaLatRad := ToRadians(p2.Latitude - p1.Latitude)
+	deltaLngRad := ToRadians(p2.Longitude - p1.Longitude)
 
 	a := math.Sin(deltaLatRad/2)*math.Sin(deltaLatRad/2) +
 		math.Cos(lat1Rad)*math.Cos(lat2Rad)*
@@ -58,27 +56,122 @@ func HaversineDistance(p1, p2 Point) float64 {
 	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
 }
 
-func toRadians(degrees float64) float64 {
+// ToRadians converts an angle in degrees to radians.
+func ToRadians(degrees float64) float64 {
 	return degrees * math.Pi / 180
 }
 
-func ParsePoint(s string) (Point, error) {
-	parts := strings.Split(s, ",")
-	if len(parts) != 2 {
-		return Point{}, fmt.Errorf("invalid point format: %s, expected lat,lng", s)
+// InitialBearing returns the great-circle bearing in degrees [0, 360) at
+// which to leave p to reach to.
+func (p Point) InitialBearing(to Point) float64 {
+	lat1 := ToRadians(p.Latitude)
+	lat2 := ToRadians(to.Latitude)
+	deltaLng := ToRadians(to.Longitude - p.Longitude)
+
+	y := math.Sin(deltaLng) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) -
+		math.Sin(lat1)*math.Cos(lat2)*math.Cos(deltaLng)
+
+	return normalizeBearing(toDegrees(math.Atan2(y, x)))
+}
+
+// FinalBearing returns the great-circle bearing in degrees [0, 360) on
+// arrival at to.
+func (p Point) FinalBearing(to Point) float64 {
+	return normalizeBearing(to.InitialBearing(p) + 180)
+}
+
+// Destination returns the point reached by travelling distanceKm along a
+// great circle from p, starting at the given bearing in degrees.
+func (p Point) Destination(bearing, distanceKm float64) Point {
+	lat1 := ToRadians(p.Latitude)
+	lng1 := ToRadians(p.Longitude)
+	theta := ToRadians(bearing)
+	delta := distanceKm / earthRadiusKm
+
+	sinLat2 := math.Sin(lat1)*math.Cos(delta) +
+		math.Cos(lat1)*math.Sin(delta)*math.Cos(theta)
+	lat2 := math.Asin(math.Max(-1, math.Min(1, sinLat2)))
+	lng2 := lng1 + math.Atan2(
+		math.Sin(theta)*math.Sin(delta)*math.Cos(lat1),
+		math.Cos(delta)-math.Sin(lat1)*sinLat2,
+	)
+
+	return Point{
+		Latitude:  toDegrees(lat2),
+		Longitude: NormalizeLongitude(toDegrees(lng2)),
+	}
+}
+
+// Midpoint returns the point halfway between p and to along the great
+// circle. For antipodal points the great circle is not unique and the
+// result is one of the possible midpoints.
+func (p Point) Midpoint(to Point) Point {
+	lat1 := ToRadians(p.Latitude)
+	lat2 := ToRadians(to.Latitude)
+	lng1 := ToRadians(p.Longitude)
+	deltaLng := ToRadians(to.Longitude - p.Longitude)
+
+	bx := math.Cos(lat2) * math.Cos(deltaLng)
+	by := math.Cos(lat2) * math.Sin(deltaLng)
+
+	lat := math.Atan2(math.Sin(lat1)+math.Sin(lat2),
+		math.Hypot(math.Cos(lat1)+bx, by))
+	lng := lng1 + math.Atan2(by, math.Cos(lat1)+bx)
+
+	return Point{
+		Latitude:  toDegrees(lat),
+		Longitude: NormalizeLongitude(toDegrees(lng)),
 	}
+}
+
+// IntermediatePoint returns the point at the given fraction of the way from
+// p to to along the great circle; 0 is p and 1 is to. The great circle
+// through antipodal points is not unique, so they are rejected.
+func (p Point) IntermediatePoint(to Point, fraction float64) (Point, error) {
+	delta := HaversineDistance(p, to) / earthRadiusKm
+	sinDelta := math.Sin(delta)
 
-	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
-	if err != nil {
-		return Point{}, fmt.Errorf("invalid latitude: %s", parts[0])
+	if delta == 0 {
+		return p, nil
 	}
+	if math.Abs(sinDelta) < 1e-12 {
+		return Point{}, fmt.Errorf("intermediate point between antipodal points %s and %s is undefined", p, to)
+	}
+
+	lat1, lng1 := ToRadians(p.Latitude), ToRadians(p.Longitude)
+	lat2, lng2 := ToRadians(to.Latitude), ToRadians(to.Longitude)
 
-	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-	if err != nil {
-		return Point{}, fmt.Errorf("invalid longitude: %s", parts[1])
+	a := math.Sin((1-fraction)*delta) / sinDelta
+	b := math.Sin(fraction*delta) / sinDelta
+
+	x := a*math.Cos(lat1)*math.Cos(lng1) + b*math.Cos(lat2)*math.Cos(lng2)
+	y := a*math.Cos(lat1)*math.Sin(lng1) + b*math.Cos(lat2)*math.Sin(lng2)
+	z := a*math.Sin(lat1) + b*math.Sin(lat2)
+
+	return Point{
+		Latitude:  toDegrees(math.Atan2(z, math.Hypot(x, y))),
+		Longitude: NormalizeLongitude(toDegrees(math.Atan2(y, x))),
+	}, nil
+}
+
+// IntermediatePoints splits the great circle from p to to into n equal
+// segments and returns the n+1 points bounding them, including both ends.
+func (p Point) IntermediatePoints(to Point, n int) ([]Point, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("number of segments must be positive, got %d", n)
 	}
 
-	return New(lat, lng)
+	points := make([]Point, 0, n+1)
+	for i := 0; i <= n; i++ {
+		pt, err := p.IntermediatePoint(to, float64(i)/float64(n))
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+	}
+
+	return points, nil
 }
 
 func ParsePoints(pointStrings []string) ([]Point, error) {
@@ -252,7 +252,7 @@ func TestToRadians(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			result := toRadians(tt.degrees)
+			result := ToRadians(tt.degrees)
 			require.InDelta(t, tt.expected, result, 0.000001)
 		})
 	}
@@ -354,3 +354,168 @@ func TestParsePoints(t *testing.T) {
 		})
 	}
 }
+
+func TestBearings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		from    Point
+		to      Point
+		initial float64
+		final   float64
+	}{
+		{"Due north", Point{Latitude: 0, Longitude: 0}, Point{Latitude: 10, Longitude: 0}, 0, 0},
+		{"Due south", Point{Latitude: 10, Longitude: 0}, Point{Latitude: 0, Longitude: 0}, 180, 180},
+		{"Due east along equator", Point{Latitude: 0, Longitude: 0}, Point{Latitude: 0, Longitude: 10}, 90, 90},
+		{"Due west along equator", Point{Latitude: 0, Longitude: 10}, Point{Latitude: 0, Longitude: 0}, 270, 270},
+		{"East across antimeridian", Point{Latitude: 0, Longitude: 179}, Point{Latitude: 0, Longitude: -179}, 90, 90},
+		{
+			"Baghdad to Osaka",
+			Point{Latitude: 35, Longitude: 45},
+			Point{Latitude: 35, Longitude: 135},
+			60.16243352168622,
+			119.83756647831379,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.InDelta(t, tt.initial, tt.from.InitialBearing(tt.to), 1e-9)
+			require.InDelta(t, tt.final, tt.from.FinalBearing(tt.to), 1e-9)
+		})
+	}
+}
+
+func TestDestination(t *testing.T) {
+	t.Parallel()
+
+	quarter := earthRadiusKm * math.Pi / 2
+
+	tests := []struct {
+		name       string
+		start      Point
+		bearing    float64
+		distanceKm float64
+		expected   Point
+	}{
+		{"Zero distance", Point{Latitude: 55.7558, Longitude: 37.6176}, 45, 0, Point{Latitude: 55.7558, Longitude: 37.6176}},
+		{"Quarter east along equator", Point{Latitude: 0, Longitude: 0}, 90, quarter, Point{Latitude: 0, Longitude: 90}},
+		{"Quarter north reaches the pole", Point{Latitude: 0, Longitude: 0}, 0, quarter, Point{Latitude: 90, Longitude: 0}},
+		{"Across antimeridian", Point{Latitude: 0, Longitude: 179}, 90, 2 * quarter / 90, Point{Latitude: 0, Longitude: -179}},
+		{"Westward across antimeridian", Point{Latitude: 0, Longitude: -179}, 270, 2 * quarter / 90, Point{Latitude: 0, Longitude: 179}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := tt.start.Destination(tt.bearing, tt.distanceKm)
+			require.InDelta(t, tt.expected.Latitude, result.Latitude, 1e-9)
+			require.InDelta(t, tt.expected.Longitude, result.Longitude, 1e-9)
+		})
+	}
+}
+
+func TestDestinationRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	moscow := Point{Latitude: 55.7558, Longitude: 37.6176}
+	targets := []Point{
+		{Latitude: 59.9311, Longitude: 30.3609},
+		{Latitude: -33.8688, Longitude: 151.2093},
+		{Latitude: 40.7128, Longitude: -74.0060},
+	}
+
+	for _, target := range targets {
+		result := moscow.Destination(moscow.InitialBearing(target), HaversineDistance(moscow, target))
+		require.InDelta(t, target.Latitude, result.Latitude, 1e-9)
+		require.InDelta(t, target.Longitude, result.Longitude, 1e-9)
+	}
+}
+
+func TestMidpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		p1       Point
+		p2       Point
+		expected Point
+	}{
+		{"Along equator", Point{Latitude: 0, Longitude: 0}, Point{Latitude: 0, Longitude: 90}, Point{Latitude: 0, Longitude: 45}},
+		{"Along meridian", Point{Latitude: 10, Longitude: 0}, Point{Latitude: -10, Longitude: 0}, Point{Latitude: 0, Longitude: 0}},
+		{"Across antimeridian", Point{Latitude: 0, Longitude: 170}, Point{Latitude: 0, Longitude: -160}, Point{Latitude: 0, Longitude: -175}},
+		{"Same point", Point{Latitude: 55.7558, Longitude: 37.6176}, Point{Latitude: 55.7558, Longitude: 37.6176}, Point{Latitude: 55.7558, Longitude: 37.6176}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := tt.p1.Midpoint(tt.p2)
+			require.InDelta(t, tt.expected.Latitude, result.Latitude, 1e-9)
+			require.InDelta(t, tt.expected.Longitude, result.Longitude, 1e-9)
+
+			d1 := HaversineDistance(tt.p1, result)
+			d2 := HaversineDistance(result, tt.p2)
+			require.InDelta(t, d1, d2, 1e-6)
+		})
+	}
+}
+
+func TestIntermediatePoint(t *testing.T) {
+	t.Parallel()
+
+	moscow := Point{Latitude: 55.7558, Longitude: 37.6176}
+	newYork := Point{Latitude: 40.7128, Longitude: -74.0060}
+	total := HaversineDistance(moscow, newYork)
+
+	for _, fraction := range []float64{0, 0.25, 0.5, 0.75, 1} {
+		result, err := moscow.IntermediatePoint(newYork, fraction)
+		require.NoError(t, err)
+		require.InDelta(t, fraction*total, HaversineDistance(moscow, result), 1e-6)
+		require.InDelta(t, (1-fraction)*total, HaversineDistance(result, newYork), 1e-6)
+	}
+
+	half, err := moscow.IntermediatePoint(newYork, 0.5)
+	require.NoError(t, err)
+	mid := moscow.Midpoint(newYork)
+	require.InDelta(t, mid.Latitude, half.Latitude, 1e-9)
+	require.InDelta(t, mid.Longitude, half.Longitude, 1e-9)
+
+	same, err := moscow.IntermediatePoint(moscow, 0.3)
+	require.NoError(t, err)
+	require.Equal(t, moscow, same)
+
+	_, err = Point{Latitude: 0, Longitude: 0}.IntermediatePoint(Point{Latitude: 0, Longitude: 180}, 0.5)
+	require.Error(t, err)
+}
+
+func TestIntermediatePoints(t *testing.T) {
+	t.Parallel()
+
+	start := Point{Latitude: 0, Longitude: 170}
+	end := Point{Latitude: 0, Longitude: -170}
+
+	points, err := start.IntermediatePoints(end, 4)
+	require.NoError(t, err)
+	require.Len(t, points, 5)
+
+	expected := []float64{170, 175, 180, -175, -170}
+	for i, p := range points {
+		require.InDelta(t, 0, p.Latitude, 1e-9)
+		lng := p.Longitude
+		if expected[i] == 180 && lng < 0 {
+			lng += 360
+		}
+		require.InDelta(t, expected[i], lng, 1e-9)
+		require.GreaterOrEqual(t, p.Longitude, -180.0)
+		require.LessOrEqual(t, p.Longitude, 180.0)
+	}
+
+	_, err = start.IntermediatePoints(end, 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package point
+
+import "errors"
+
+var (
+	ErrNoPoints   = errors.New("no points given")
+	ErrEmptyRoute = errors.New("route has no points")
+)
+
+// RouteStats summarises an ordered route.
+type RouteStats struct {
+	LengthKm   float64
+	SegmentsKm []float64
+	SW         Point
+	NE         Point
+	BBox       BBox
+}
+
+// SegmentDistances returns the Haversine distance of each leg of the route,
+// one fewer than the number of points.
+func SegmentDistances(points []Point) []float64 {
+	if len(points) < 2 {
+		return nil
+	}
+
+	result := make([]float64, len(points)-1)
+	for i := range result {
+		result[i] = HaversineDistance(points[i], points[i+1])
+	}
+	return result
+}
+
+// RouteLength returns the total Haversine length of the route in km.
+func RouteLength(points []Point) float64 {
+	var total float64
+	for _, d := range SegmentDistances(points) {
+		total += d
+	}
+	return total
+}
+
+// BoundingBox returns the south-west and north-east corners of the smallest
+// box containing points. When the points are closer together across the
+// antimeridian than around the globe the other way, sw is east of ne, as
+// accepted by Index.WithinBBox.
+func BoundingBox(points []Point) (sw, ne Point, err error) {
+	if len(points) == 0 {
+		return Point{}, Point{}, ErrEmptyRoute
+	}
+
+	box, err := BBoxFromPoints(points)
+	if err != nil {
+		return Point{}, Point{}, err
+	}
+	return box.SW, box.NE, nil
+}
+
+// Stats returns the length, leg distances and bounding box of the route.
+func Stats(points []Point) (RouteStats, error) {
+	sw, ne, err := BoundingBox(points)
+	if err != nil {
+		return RouteStats{}, err
+	}
+
+	return RouteStats{
+		LengthKm:   RouteLength(points),
+		SegmentsKm: SegmentDistances(points),
+		SW:         sw,
+		NE:         ne,
+		BBox:       BBox{SW: sw, NE: ne},
+	}, nil
+}
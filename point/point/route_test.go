@@ -0,0 +1,101 @@
+package point
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegmentDistancesAndRouteLength(t *testing.T) {
+	t.Parallel()
+
+	moscow := Point{Latitude: 55.7558, Longitude: 37.6176}
+	spb := Point{Latitude: 59.9311, Longitude: 30.3609}
+	ekb := Point{Latitude: 56.8431, Longitude: 60.6454}
+
+	route := []Point{moscow, spb, ekb}
+	segments := SegmentDistances(route)
+	require.Len(t, segments, 2)
+	require.InDelta(t, HaversineDistance(moscow, spb), segments[0], 1e-12)
+	require.InDelta(t, HaversineDistance(spb, ekb), segments[1], 1e-12)
+	require.InDelta(t, segments[0]+segments[1], RouteLength(route), 1e-12)
+
+	require.Nil(t, SegmentDistances([]Point{moscow}))
+	require.Zero(t, RouteLength(nil))
+}
+
+func TestBoundingBox(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		points    []Point
+		sw        Point
+		ne        Point
+		shouldErr bool
+	}{
+		{
+			"Single point",
+			[]Point{{Latitude: 10, Longitude: 20}},
+			Point{Latitude: 10, Longitude: 20},
+			Point{Latitude: 10, Longitude: 20},
+			false,
+		},
+		{
+			"Regular route",
+			[]Point{{Latitude: 55.7558, Longitude: 37.6176}, {Latitude: 59.9311, Longitude: 30.3609}, {Latitude: 56.8431, Longitude: 60.6454}},
+			Point{Latitude: 55.7558, Longitude: 30.3609},
+			Point{Latitude: 59.9311, Longitude: 60.6454},
+			false,
+		},
+		{
+			"Crosses antimeridian",
+			[]Point{{Latitude: -17, Longitude: 178}, {Latitude: -14, Longitude: -172}, {Latitude: -18, Longitude: 179}},
+			Point{Latitude: -18, Longitude: 178},
+			Point{Latitude: -14, Longitude: -172},
+			false,
+		},
+		{"Empty", nil, Point{}, Point{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sw, ne, err := BoundingBox(tt.points)
+
+			if tt.shouldErr {
+				require.ErrorIs(t, err, ErrEmptyRoute)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.sw, sw)
+			require.Equal(t, tt.ne, ne)
+			for _, p := range tt.points {
+				require.True(t, inBBox(p, sw, ne))
+			}
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	route := []Point{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 1},
+		{Latitude: 1, Longitude: 1},
+	}
+
+	stats, err := Stats(route)
+	require.NoError(t, err)
+	require.Equal(t, SegmentDistances(route), stats.SegmentsKm)
+	require.InDelta(t, RouteLength(route), stats.LengthKm, 1e-12)
+	require.Equal(t, Point{Latitude: 0, Longitude: 0}, stats.SW)
+	require.Equal(t, Point{Latitude: 1, Longitude: 1}, stats.NE)
+	require.Equal(t, BBox{SW: stats.SW, NE: stats.NE}, stats.BBox)
+
+	_, err = Stats(nil)
+	require.ErrorIs(t, err, ErrEmptyRoute)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"polygon/polygon"
+)
+
+// formatOf guesses the format of a file from its extension.
+func formatOf(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".wkt":
+		return "wkt", nil
+	case ".wkb":
+		return "wkb", nil
+	case ".json", ".geojson":
+		return "geojson", nil
+	default:
+		return "", fmt.Errorf("cannot tell the format of %q, use -format", path)
+	}
+}
+
+// loadPolygons reads polygons from path, or from stdin when path is "-".
+// WKB may be raw or hex encoded.
+func loadPolygons(path, format string) (polygon.MultiPolygon, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "wkt":
+		return polygon.ParseWKT(string(data))
+	case "wkb":
+		if decoded, err := hex.DecodeString(string(bytes.TrimSpace(data))); err == nil {
+			data = decoded
+		}
+		return polygon.ParseWKB(data)
+	case "geojson":
+		_, mp, err := polygon.ReadGeoJSON(bytes.NewReader(data))
+		return mp, err
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected wkt, wkb or geojson", format)
+	}
+}
+
+// writePolygons writes mp to w in the given format, with WKB hex encoded.
+func writePolygons(w io.Writer, mp polygon.MultiPolygon, format string) error {
+	var err error
+	switch format {
+	case "wkt":
+		_, err = fmt.Fprintln(w, mp.WKT())
+	case "wkb":
+		_, err = fmt.Fprintln(w, hex.EncodeToString(mp.WKB(polygon.LittleEndian)))
+	case "geojson":
+		err = polygon.WriteGeoJSON(w, polygon.GeoJSONMultiPolygon, mp)
+	default:
+		err = fmt.Errorf("unknown format %q, expected wkt, wkb or geojson", format)
+	}
+	return err
+}
+
+// describe prints the area, perimeter and validity of each part of mp.
+func describe(mp polygon.MultiPolygon) {
+	for i, p := range mp {
+		validity := "valid"
+		if err := p.Validate(); err != nil {
+			validity = err.Error()
+		}
+		fmt.Printf("Part %d: %d vertices, %d holes, area %.2f, perimeter %.2f, %s\n",
+			i, len(p.Outer), len(p.Holes), p.Area(), p.Perimeter(), validity)
+	}
+}
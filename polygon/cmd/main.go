@@ -1,30 +1,125 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
+	"os"
 	"polygon/polygon"
+
+	"point/point"
 )
 
+// go run . -in zones.wkt
+// go run . -in zones.geojson -out wkb
+// echo 'POLYGON ((0 0, 4 0, 2 3, 0 0))' | go run . -in - -format wkt -out geojson
+
 func main() {
-	triangle := []polygon.Point{{0, 0}, {4, 0}, {2, 3}}
-	poly, err := polygon.NewPolygon(triangle)
+	in := flag.String("in", "", "Read polygons from a WKT, WKB or GeoJSON file ('-' for stdin) instead of running the demo")
+	format := flag.String("format", "", "Format of the input: wkt, wkb or geojson (default from the file extension)")
+	out := flag.String("out", "", "Write the polygons to stdout as wkt, wkb (hex) or geojson")
+	flag.Parse()
+
+	if *in != "" {
+		if *format == "" {
+			var err error
+			if *format, err = formatOf(*in); err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+		}
+		mp, err := loadPolygons(*in, *format)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		if *out != "" {
+			if err := writePolygons(os.Stdout, mp, *out); err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			return
+		}
+		describe(mp)
+		return
+	}
+
+	triangle := []polygon.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 3}}
+	poly, err := polygon.New(triangle)
 	if err != nil {
 		fmt.Printf("Error creating polygon: %v\n", err)
 		return
 	}
 
-	fmt.Printf("Triangle with vertices: %v\n", poly.Vertices)
+	fmt.Printf("Triangle with vertices: %v\n", poly)
 	fmt.Printf("Area: %.2f\n", poly.Area())
 	fmt.Printf("Perimeter: %.2f\n", poly.Perimeter())
 
 	fmt.Println()
 
-	square := []polygon.Point{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
-	poly, _ = polygon.NewPolygon(square)
+	square := []polygon.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}}
+	poly, _ = polygon.New(square)
 
-	fmt.Printf("Square with vertices: %v\n", poly.Vertices)
+	fmt.Printf("Square with vertices: %v\n", poly)
 	fmt.Printf("Area: %.2f\n", poly.Area())
 	fmt.Printf("Perimeter: %.2f\n", poly.Perimeter())
 
 	fmt.Println()
+
+	zone := []polygon.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 3}, {X: 4, Y: 3}, {X: 4, Y: 4}, {X: 0, Y: 4}}
+	poly, _ = polygon.New(zone)
+
+	fmt.Printf("Delivery zone with vertices: %v\n", poly)
+	fmt.Printf("Orientation: %s, convex: %t\n", poly.Orientation(), poly.IsConvex())
+	if c, err := poly.Centroid(); err == nil {
+		fmt.Printf("Centroid: %v\n", c)
+	}
+	for _, p := range []polygon.Point{{X: 0.5, Y: 2}, {X: 3, Y: 2}, {X: 4, Y: 0.5}} {
+		fmt.Printf("  %v is %s\n", p, poly.Locate(p))
+	}
+
+	fmt.Println()
+
+	extension, _ := polygon.New([]polygon.Point{{X: 3, Y: 0}, {X: 5, Y: 0}, {X: 5, Y: 4}, {X: 3, Y: 4}})
+	for _, op := range []polygon.Op{polygon.OpUnion, polygon.OpIntersection, polygon.OpDifference, polygon.OpXor} {
+		fmt.Printf("Zone %s extension:\n", op)
+		for _, part := range polygon.Clip(poly, extension, op) {
+			fmt.Printf("  %v with holes %v\n", part.Outer, part.Holes)
+		}
+	}
+
+	fmt.Println()
+
+	for _, method := range []polygon.TriangulationMethod{polygon.EarClipping, polygon.ConstrainedDelaunay} {
+		if triangles, err := poly.Triangulate(method); err == nil {
+			fmt.Printf("Zone triangles by %s: %v\n", method, triangles)
+		}
+	}
+
+	fmt.Println()
+
+	stops := []polygon.Point{{X: 1, Y: 1}, {X: 5, Y: 0}, {X: 6, Y: 3}, {X: 3, Y: 2}, {X: 2, Y: 5}, {X: 0, Y: 3}, {X: 3, Y: 4}}
+	fmt.Printf("Convex hull of stops: %v\n", polygon.ConvexHull(stops))
+	if rect, err := polygon.MinAreaRectangle(stops); err == nil {
+		fmt.Printf("Smallest rectangle area: %.2f\n", rect.Area())
+	}
+	if circle, err := polygon.MinEnclosingCircle(stops); err == nil {
+		fmt.Printf("Smallest circle: centre %v, radius %.2f\n", circle.Center, circle.Radius)
+	}
+
+	fmt.Println()
+
+	district, err := polygon.NewGeoPolygon([]point.Point{
+		{Latitude: 52.50, Longitude: 13.35},
+		{Latitude: 52.50, Longitude: 13.45},
+		{Latitude: 52.55, Longitude: 13.45},
+		{Latitude: 52.55, Longitude: 13.35},
+	})
+	if err != nil {
+		fmt.Printf("Error creating district: %v\n", err)
+		return
+	}
+
+	fmt.Printf("District with vertices: %v\n", district)
+	fmt.Printf("Area: %.2f km² (%.4f square degrees)\n", district.Area(), district.Planar().Area())
+	fmt.Printf("Perimeter: %.2f km\n", district.Perimeter())
+
+	fmt.Println()
 }
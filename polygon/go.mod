@@ -2,9 +2,15 @@ module polygon
 
 go 1.24.4
 
+require (
+	github.com/stretchr/testify v1.11.1
+	point v0.0.0
+)
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.11.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace point => ../point
@@ -0,0 +1,385 @@
+package polygon
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// Op is a boolean operation on polygons.
+type Op int
+
+const (
+	OpUnion Op = iota
+	OpIntersection
+	OpDifference
+	OpXor
+)
+
+func (op Op) String() string {
+	switch op {
+	case OpUnion:
+		return "union"
+	case OpIntersection:
+		return "intersection"
+	case OpDifference:
+		return "difference"
+	case OpXor:
+		return "xor"
+	default:
+		return "unknown"
+	}
+}
+
+// Union returns the area covered by either polygon.
+func (pg Polygon) Union(other Polygon) MultiPolygon {
+	return Clip(pg, other, OpUnion)
+}
+
+// Intersection returns the area covered by both polygons.
+func (pg Polygon) Intersection(other Polygon) MultiPolygon {
+	return Clip(pg, other, OpIntersection)
+}
+
+// Difference returns the area covered by pg but not by other.
+func (pg Polygon) Difference(other Polygon) MultiPolygon {
+	return Clip(pg, other, OpDifference)
+}
+
+// Xor returns the area covered by exactly one of the polygons.
+func (pg Polygon) Xor(other Polygon) MultiPolygon {
+	return Clip(pg, other, OpXor)
+}
+
+// Clip applies op to the simple polygons a and b, which may be concave and
+// in either orientation; use Validate to check them first. The result may
+// consist of several polygons, each with holes, and contains no collinear
+// vertices. Parts that meet only along a line or at a point are omitted
+// from intersections.
+//
+// Both boundaries are split wherever they cross or touch, each piece is
+// kept or dropped according to whether it runs inside or outside the other
+// polygon, and the kept pieces are joined back into rings.
+func Clip(a, b Polygon, op Op) MultiPolygon {
+	return clipRings([]Polygon{ccw(a)}, []Polygon{ccw(b)}, op)
+}
+
+// clipRings applies op to two regions, each given as rings oriented with
+// the interior on their left.
+func clipRings(a, b []Polygon, op Op) MultiPolygon {
+	edgesA := splitEdges(a, b)
+	edgesB := splitEdges(b, a)
+
+	// Pieces of either boundary, to find those the two have in common.
+	inA := make(map[[2]Point]bool, len(edgesA))
+	for _, e := range edgesA {
+		inA[e] = true
+	}
+	inB := make(map[[2]Point]bool, len(edgesB))
+	for _, e := range edgesB {
+		inB[e] = true
+	}
+
+	var kept [][2]Point
+	for _, e := range edgesA {
+		reversed := [2]Point{e[1], e[0]}
+		switch {
+		case inB[e]:
+			// Both regions lie on the same side of the edge.
+			if op == OpUnion || op == OpIntersection {
+				kept = append(kept, e)
+			}
+		case inB[reversed]:
+			// The regions lie on opposite sides of the edge.
+			if op == OpDifference {
+				kept = append(kept, e)
+			}
+		default:
+			inside := locateRings(b, midpoint(e)) == Inside
+			switch {
+			case inside && op == OpIntersection:
+				kept = append(kept, e)
+			case inside && op == OpXor:
+				kept = append(kept, reversed)
+			case !inside && op != OpIntersection:
+				kept = append(kept, e)
+			}
+		}
+	}
+
+	for _, e := range edgesB {
+		reversed := [2]Point{e[1], e[0]}
+		if inA[e] || inA[reversed] {
+			continue
+		}
+
+		inside := locateRings(a, midpoint(e)) == Inside
+		switch {
+		case inside && op == OpIntersection:
+			kept = append(kept, e)
+		case inside && (op == OpDifference || op == OpXor):
+			kept = append(kept, reversed)
+		case !inside && (op == OpUnion || op == OpXor):
+			kept = append(kept, e)
+		}
+	}
+
+	return assemble(traceRings(kept))
+}
+
+func midpoint(e [2]Point) Point {
+	return Point{(e[0].X + e[1].X) / 2, (e[0].Y + e[1].Y) / 2}
+}
+
+// ccw returns pg ordered counter-clockwise.
+func ccw(pg Polygon) Polygon {
+	if pg.SignedArea() >= 0 {
+		return pg
+	}
+	return pg.Reverse()
+}
+
+// splitEdges returns the directed edges of rings, split at every point
+// where they cross or touch an edge of others.
+func splitEdges(rings, others []Polygon) [][2]Point {
+	var result [][2]Point
+	for _, ring := range rings {
+		n := len(ring)
+		for i := 0; i < n; i++ {
+			a, b := ring[i], ring[(i+1)%n]
+			if a == b {
+				continue
+			}
+
+			cuts := []Point{a, b}
+			for _, other := range others {
+				m := len(other)
+				for j := 0; j < m; j++ {
+					cuts = append(cuts, intersections(a, b, other[j], other[(j+1)%m])...)
+				}
+			}
+
+			// Order the cuts along the edge and drop repeats.
+			dx, dy := b.X-a.X, b.Y-a.Y
+			sort.Slice(cuts, func(i, j int) bool {
+				return (cuts[i].X-a.X)*dx+(cuts[i].Y-a.Y)*dy < (cuts[j].X-a.X)*dx+(cuts[j].Y-a.Y)*dy
+			})
+			for k := 1; k < len(cuts); k++ {
+				if cuts[k] != cuts[k-1] {
+					result = append(result, [2]Point{cuts[k-1], cuts[k]})
+				}
+			}
+		}
+	}
+	return result
+}
+
+// intersections returns the points where segment cd meets segment ab,
+// excluding a and b themselves. Endpoints are returned exactly, so both
+// polygons split at identical points where their vertices touch.
+func intersections(a, b, c, d Point) []Point {
+	d1, d2 := cross(a, b, c), cross(a, b, d)
+	d3, d4 := cross(c, d, a), cross(c, d, b)
+
+	var result []Point
+	add := func(p Point) {
+		if p != a && p != b {
+			result = append(result, p)
+		}
+	}
+
+	if d1 == 0 && d2 == 0 {
+		// Collinear: each endpoint of cd inside ab is a cut.
+		if onSegment(c, a, b) {
+			add(c)
+		}
+		if onSegment(d, a, b) {
+			add(d)
+		}
+		return result
+	}
+
+	switch {
+	case d1 == 0 && onSegment(c, a, b):
+		add(c)
+	case d2 == 0 && onSegment(d, a, b):
+		add(d)
+	case d1 != 0 && d2 != 0 && d3 != 0 && d4 != 0 && (d1 > 0) != (d2 > 0) && (d3 > 0) != (d4 > 0):
+		add(crossingPoint(a, b, c, d))
+	}
+	return result
+}
+
+// crossingPoint returns the point where segments ab and cd cross. The
+// segments are put in a canonical order first, so the same two edges give
+// a bit-identical point whichever polygon they are split for.
+func crossingPoint(a, b, c, d Point) Point {
+	if less(b, a) {
+		a, b = b, a
+	}
+	if less(d, c) {
+		c, d = d, c
+	}
+	if less(c, a) || (c == a && less(d, b)) {
+		a, b, c, d = c, d, a, b
+	}
+
+	t := cross(c, d, a) / (cross(c, d, a) - cross(c, d, b))
+	return Point{a.X + t*(b.X-a.X), a.Y + t*(b.Y-a.Y)}
+}
+
+func less(p, q Point) bool {
+	return p.X < q.X || (p.X == q.X && p.Y < q.Y)
+}
+
+// traceRings joins directed edges end to end into closed rings. Where
+// several edges leave a vertex, the sharpest left turn is taken, and a ring
+// closes as soon as it returns to its first vertex. A ring that passes
+// through a vertex twice is cut there, so rings that touch at a vertex come
+// out separately. Walks that never close are dropped.
+func traceRings(edges [][2]Point) []Polygon {
+	outgoing := make(map[Point][]int)
+	for i, e := range edges {
+		outgoing[e[0]] = append(outgoing[e[0]], i)
+	}
+
+	used := make([]bool, len(edges))
+	var rings []Polygon
+
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+
+		var walk Polygon
+		closed := false
+		for cur := start; cur >= 0 && !used[cur]; {
+			used[cur] = true
+			e := edges[cur]
+			walk = append(walk, e[0])
+
+			if e[1] == edges[start][0] {
+				closed = true
+				break
+			}
+
+			next, best := -1, math.Inf(-1)
+			for _, candidate := range outgoing[e[1]] {
+				if used[candidate] {
+					continue
+				}
+				if turn := turnAngle(e[0], e[1], edges[candidate][1]); turn > best {
+					next, best = candidate, turn
+				}
+			}
+			cur = next
+		}
+
+		if !closed {
+			continue
+		}
+
+		for _, ring := range splitLoops(walk) {
+			ring = dropCollinear(ring)
+			if len(ring) >= 3 && ring.SignedArea() != 0 {
+				rings = append(rings, ring)
+			}
+		}
+	}
+
+	return rings
+}
+
+// splitLoops cuts a closed walk at every vertex it returns to, giving one
+// loop per return and the rest of the walk, none of which repeat a vertex.
+func splitLoops(walk Polygon) []Polygon {
+	var loops []Polygon
+	var stack Polygon
+	at := make(map[Point]int)
+	for _, p := range walk {
+		i, ok := at[p]
+		if !ok {
+			at[p] = len(stack)
+			stack = append(stack, p)
+			continue
+		}
+
+		loops = append(loops, slices.Clone(stack[i:]))
+		for _, q := range stack[i+1:] {
+			delete(at, q)
+		}
+		stack = stack[:i+1]
+	}
+	return append(loops, stack)
+}
+
+// turnAngle returns the signed angle of the turn a→b→c, positive to the
+// left.
+func turnAngle(a, b, c Point) float64 {
+	ux, uy := b.X-a.X, b.Y-a.Y
+	vx, vy := c.X-b.X, c.Y-b.Y
+	return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+}
+
+// dropCollinear removes vertices lying on the straight line between their
+// neighbours.
+func dropCollinear(ring Polygon) Polygon {
+	for changed := true; changed && len(ring) >= 3; {
+		changed = false
+		for i := 0; i < len(ring) && len(ring) >= 3; i++ {
+			prev := ring[(i+len(ring)-1)%len(ring)]
+			next := ring[(i+1)%len(ring)]
+			if cross(prev, ring[i], next) == 0 {
+				ring = append(ring[:i:i], ring[i+1:]...)
+				changed = true
+				i--
+			}
+		}
+	}
+	return ring
+}
+
+// assemble groups counter-clockwise outer rings with the clockwise holes
+// they enclose, giving each hole to the smallest outer ring around it.
+func assemble(rings []Polygon) MultiPolygon {
+	var result MultiPolygon
+	var holes []Polygon
+	for _, ring := range rings {
+		if ring.SignedArea() > 0 {
+			result = append(result, WithHoles{Outer: ring})
+		} else {
+			holes = append(holes, ring)
+		}
+	}
+
+	for _, hole := range holes {
+		owner, ownerArea := -1, math.Inf(1)
+		for i, p := range result {
+			area := p.Outer.SignedArea()
+			if area < ownerArea && enclosesRing(p.Outer, hole) {
+				owner, ownerArea = i, area
+			}
+		}
+		if owner >= 0 {
+			result[owner].Holes = append(result[owner].Holes, hole)
+		}
+	}
+
+	return result
+}
+
+// enclosesRing reports whether inner lies within outer, judged by the
+// first vertex or edge midpoint of inner not on outer's boundary.
+func enclosesRing(outer, inner Polygon) bool {
+	for i := range inner {
+		for _, p := range []Point{inner[i], midpoint([2]Point{inner[i], inner[(i+1)%len(inner)]})} {
+			switch outer.Locate(p) {
+			case Inside:
+				return true
+			case Outside:
+				return false
+			}
+		}
+	}
+	return false
+}
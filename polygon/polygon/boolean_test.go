@@ -0,0 +1,353 @@
+package polygon
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sameRing reports whether two rings hold the same vertices in the same
+// cyclic order, whatever vertex they start at.
+func sameRing(expected, actual Polygon) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+
+	for shift := range actual {
+		match := true
+		for i := range expected {
+			if expected[i] != actual[(i+shift)%len(actual)] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func samePolygon(expected, actual WithHoles) bool {
+	if !sameRing(expected.Outer, actual.Outer) || len(expected.Holes) != len(actual.Holes) {
+		return false
+	}
+	for i := range expected.Holes {
+		if !sameRing(expected.Holes[i], actual.Holes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestClipFixtures(t *testing.T) {
+	square := Polygon{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	shifted := Polygon{{1, 1}, {3, 1}, {3, 3}, {1, 3}}
+	cShape := Polygon{{0, 0}, {4, 0}, {4, 1}, {1, 1}, {1, 3}, {4, 3}, {4, 4}, {0, 4}}
+	bar := Polygon{{3, 0}, {5, 0}, {5, 4}, {3, 4}}
+	neighbour := Polygon{{2, 0}, {4, 0}, {4, 2}, {2, 2}}
+	far := Polygon{{10, 10}, {11, 10}, {11, 11}, {10, 11}}
+	inner := Polygon{{0.5, 0.5}, {1.5, 0.5}, {1.5, 1.5}, {0.5, 1.5}}
+
+	tests := []struct {
+		name     string
+		a        Polygon
+		b        Polygon
+		op       Op
+		expected MultiPolygon
+	}{
+		{
+			name:     "overlapping squares union",
+			a:        square,
+			b:        shifted,
+			op:       OpUnion,
+			expected: MultiPolygon{{Outer: Polygon{{0, 0}, {2, 0}, {2, 1}, {3, 1}, {3, 3}, {1, 3}, {1, 2}, {0, 2}}}},
+		},
+		{
+			name:     "overlapping squares intersection",
+			a:        square,
+			b:        shifted,
+			op:       OpIntersection,
+			expected: MultiPolygon{{Outer: Polygon{{1, 1}, {2, 1}, {2, 2}, {1, 2}}}},
+		},
+		{
+			name:     "overlapping squares difference",
+			a:        square,
+			b:        shifted,
+			op:       OpDifference,
+			expected: MultiPolygon{{Outer: Polygon{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}}}},
+		},
+		{
+			name: "concave union encloses a hole",
+			a:    cShape,
+			b:    bar,
+			op:   OpUnion,
+			expected: MultiPolygon{{
+				Outer: Polygon{{0, 0}, {5, 0}, {5, 4}, {0, 4}},
+				Holes: []Polygon{{{1, 1}, {1, 3}, {3, 3}, {3, 1}}},
+			}},
+		},
+		{
+			name: "concave intersection splits in two",
+			a:    cShape,
+			b:    bar,
+			op:   OpIntersection,
+			expected: MultiPolygon{
+				{Outer: Polygon{{3, 0}, {4, 0}, {4, 1}, {3, 1}}},
+				{Outer: Polygon{{3, 3}, {4, 3}, {4, 4}, {3, 4}}},
+			},
+		},
+		{
+			name:     "concave difference",
+			a:        bar,
+			b:        cShape,
+			op:       OpDifference,
+			expected: MultiPolygon{{Outer: Polygon{{4, 0}, {5, 0}, {5, 4}, {4, 4}, {4, 3}, {3, 3}, {3, 1}, {4, 1}}}},
+		},
+		{
+			name:     "shared edge union",
+			a:        square,
+			b:        neighbour,
+			op:       OpUnion,
+			expected: MultiPolygon{{Outer: Polygon{{0, 0}, {4, 0}, {4, 2}, {0, 2}}}},
+		},
+		{
+			name:     "shared edge intersection",
+			a:        square,
+			b:        neighbour,
+			op:       OpIntersection,
+			expected: nil,
+		},
+		{
+			name:     "shared edge difference",
+			a:        square,
+			b:        neighbour,
+			op:       OpDifference,
+			expected: MultiPolygon{{Outer: square}},
+		},
+		{
+			name:     "corner touching union",
+			a:        square,
+			b:        Polygon{{2, 2}, {4, 2}, {4, 4}, {2, 4}},
+			op:       OpUnion,
+			expected: MultiPolygon{{Outer: square}, {Outer: Polygon{{2, 2}, {4, 2}, {4, 4}, {2, 4}}}},
+		},
+		{
+			name:     "disjoint union",
+			a:        square,
+			b:        far,
+			op:       OpUnion,
+			expected: MultiPolygon{{Outer: square}, {Outer: far}},
+		},
+		{
+			name:     "disjoint intersection",
+			a:        square,
+			b:        far,
+			op:       OpIntersection,
+			expected: nil,
+		},
+		{
+			name: "contained difference leaves a hole",
+			a:    square,
+			b:    inner,
+			op:   OpDifference,
+			expected: MultiPolygon{{
+				Outer: square,
+				Holes: []Polygon{inner.Reverse()},
+			}},
+		},
+		{
+			name:     "contained union",
+			a:        square,
+			b:        inner,
+			op:       OpUnion,
+			expected: MultiPolygon{{Outer: square}},
+		},
+		{
+			name:     "identical intersection",
+			a:        square,
+			b:        square,
+			op:       OpIntersection,
+			expected: MultiPolygon{{Outer: square}},
+		},
+		{
+			name:     "identical xor",
+			a:        square,
+			b:        square,
+			op:       OpXor,
+			expected: nil,
+		},
+		{
+			name:     "clockwise inputs",
+			a:        square.Reverse(),
+			b:        shifted.Reverse(),
+			op:       OpIntersection,
+			expected: MultiPolygon{{Outer: Polygon{{1, 1}, {2, 1}, {2, 2}, {1, 2}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Clip(tt.a, tt.b, tt.op)
+			require.Len(t, result, len(tt.expected))
+
+			for _, want := range tt.expected {
+				found := false
+				for _, got := range result {
+					found = found || samePolygon(want, got)
+				}
+				require.True(t, found, "missing %v in %v", want, result)
+			}
+		})
+	}
+}
+
+func TestClipXorOfOverlappingSquares(t *testing.T) {
+	square := Polygon{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	shifted := Polygon{{1, 1}, {3, 1}, {3, 3}, {1, 3}}
+
+	result := square.Xor(shifted)
+	require.InDelta(t, 6, result.Area(), 1e-12)
+
+	rings := result.rings()
+	require.Equal(t, Inside, locateRings(rings, Point{0.5, 0.5}))
+	require.Equal(t, Inside, locateRings(rings, Point{2.5, 2.5}))
+	require.Equal(t, Outside, locateRings(rings, Point{1.5, 1.5}))
+}
+
+func TestClipRepeatedVertices(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     Polygon
+		b     Polygon
+		op    Op
+		parts int
+		holes int
+	}{
+		{
+			name:  "union enclosing a hole at a shared vertex",
+			a:     Polygon{{2, 0}, {3, 3}, {0, 1}, {-2, 2}, {-2, 0}, {-2, -2}, {0, -3}, {1, -1}},
+			b:     Polygon{{5, 2}, {3, 5}, {0, 4}, {-2, 2}, {1, 1}, {3, 0}},
+			op:    OpUnion,
+			parts: 1,
+			holes: 1,
+		},
+		{
+			name:  "xor passing a crossing point twice",
+			a:     Polygon{{1, 0}, {-2, 3}, {-1, -3}},
+			b:     Polygon{{3, 2}, {2, 4}, {1, 4}, {-1, 4}, {-1, 2}, {0, 1}, {1, -1}, {3, 0}},
+			op:    OpXor,
+			parts: 1,
+			holes: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Clip(tt.a, tt.b, tt.op)
+			require.NoError(t, result.Validate())
+			require.Len(t, result, tt.parts)
+			require.Len(t, result[0].Holes, tt.holes)
+
+			union, intersection := tt.a.Union(tt.b).Area(), tt.a.Intersection(tt.b).Area()
+			switch tt.op {
+			case OpUnion:
+				require.InDelta(t, tt.a.Area()+tt.b.Area()-intersection, result.Area(), 1e-9)
+			case OpXor:
+				require.InDelta(t, union-intersection, result.Area(), 1e-9)
+			}
+		})
+	}
+}
+
+func TestTraceRingsDropsOpenWalks(t *testing.T) {
+	rings := traceRings([][2]Point{
+		{{0, 0}, {1, 0}}, {{1, 0}, {1, 1}}, {{1, 1}, {0, 0}},
+		{{5, 5}, {6, 5}}, {{6, 5}, {6, 6}}, {{6, 6}, {5, 7}},
+	})
+	require.Equal(t, []Polygon{{{0, 0}, {1, 0}, {1, 1}}}, rings)
+}
+
+func TestPolygonBooleanMethods(t *testing.T) {
+	square := Polygon{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	shifted := Polygon{{1, 1}, {3, 1}, {3, 3}, {1, 3}}
+
+	require.InDelta(t, 7, square.Union(shifted).Area(), 1e-12)
+	require.InDelta(t, 1, square.Intersection(shifted).Area(), 1e-12)
+	require.InDelta(t, 3, square.Difference(shifted).Area(), 1e-12)
+	require.InDelta(t, 6, square.Xor(shifted).Area(), 1e-12)
+	require.Equal(t, "xor", OpXor.String())
+}
+
+// randomStar returns a simple star-shaped polygon around center.
+func randomStar(r *rand.Rand, center Point, n int) Polygon {
+	angles := make([]float64, n)
+	for i := range angles {
+		angles[i] = (float64(i) + r.Float64()*0.8) * 2 * math.Pi / float64(n)
+	}
+
+	pg := make(Polygon, n)
+	for i, a := range angles {
+		radius := 1 + r.Float64()*4
+		pg[i] = Point{center.X + radius*math.Cos(a), center.Y + radius*math.Sin(a)}
+	}
+	return pg
+}
+
+func TestClipRandomStars(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for iter := 0; iter < 200; iter++ {
+		a := randomStar(r, Point{r.Float64() * 3, r.Float64() * 3}, 3+r.IntN(12))
+		b := randomStar(r, Point{r.Float64() * 3, r.Float64() * 3}, 3+r.IntN(12))
+		require.NoError(t, a.Validate())
+		require.NoError(t, b.Validate())
+
+		areaA, areaB := a.Area(), b.Area()
+		union := a.Union(b).Area()
+		intersection := a.Intersection(b).Area()
+
+		require.InDelta(t, areaA+areaB, union+intersection, 1e-9)
+		require.InDelta(t, areaA-intersection, a.Difference(b).Area(), 1e-9)
+		require.InDelta(t, areaB-intersection, b.Difference(a).Area(), 1e-9)
+		require.InDelta(t, union-intersection, a.Xor(b).Area(), 1e-9)
+
+		for _, op := range []Op{OpUnion, OpIntersection, OpDifference, OpXor} {
+			result := Clip(a, b, op)
+			require.NoError(t, result.Validate(), "%s of %v and %v", op, a, b)
+			rings := result.rings()
+
+			for _, p := range result {
+				require.Positive(t, p.Outer.SignedArea())
+				for _, hole := range p.Holes {
+					require.Negative(t, hole.SignedArea())
+				}
+			}
+
+			for k := 0; k < 50; k++ {
+				p := Point{r.Float64()*14 - 5, r.Float64()*14 - 5}
+				locA, locB := a.Locate(p), b.Locate(p)
+				if locA == OnBoundary || locB == OnBoundary {
+					continue
+				}
+
+				inA, inB := locA == Inside, locB == Inside
+				var expected bool
+				switch op {
+				case OpUnion:
+					expected = inA || inB
+				case OpIntersection:
+					expected = inA && inB
+				case OpDifference:
+					expected = inA && !inB
+				case OpXor:
+					expected = inA != inB
+				}
+
+				require.Equal(t, expected, locateRings(rings, p) == Inside, "%s at %v", op, p)
+			}
+		}
+	}
+}
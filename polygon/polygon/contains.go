@@ -0,0 +1,87 @@
+package polygon
+
+// Location describes where a point lies relative to a polygon.
+type Location int
+
+const (
+	Outside Location = iota
+	Inside
+	OnBoundary
+)
+
+func (l Location) String() string {
+	switch l {
+	case Inside:
+		return "inside"
+	case OnBoundary:
+		return "on boundary"
+	default:
+		return "outside"
+	}
+}
+
+// Locate classifies p using the nonzero winding rule: p is inside when the
+// boundary winds around it any nonzero number of times, so regions covered
+// twice by a self-overlapping ring are inside too. Points on an edge or at
+// a vertex are reported as OnBoundary before winding is considered.
+func (pg Polygon) Locate(p Point) Location {
+	winding, onBoundary := pg.winding(p)
+	switch {
+	case onBoundary:
+		return OnBoundary
+	case winding != 0:
+		return Inside
+	default:
+		return Outside
+	}
+}
+
+// winding returns the number of times pg winds counter-clockwise around p,
+// or reports that p lies on its boundary.
+func (pg Polygon) winding(p Point) (int, bool) {
+	n := len(pg)
+	winding := 0
+
+	for i := 0; i < n; i++ {
+		a, b := pg[i], pg[(i+1)%n]
+
+		if onSegment(p, a, b) {
+			return 0, true
+		}
+
+		// Count upward crossings with p strictly left of the edge and
+		// downward crossings with p strictly right of it. Each edge owns
+		// its lower endpoint only, so a ray through a vertex is counted
+		// once and horizontal or collinear edges never count.
+		if a.Y <= p.Y {
+			if b.Y > p.Y && cross(a, b, p) > 0 {
+				winding++
+			}
+		} else if b.Y <= p.Y && cross(a, b, p) < 0 {
+			winding--
+		}
+	}
+
+	return winding, false
+}
+
+// Contains reports whether p lies inside pg or on its boundary.
+func (pg Polygon) Contains(p Point) bool {
+	return pg.Locate(p) != Outside
+}
+
+// cross returns twice the signed area of the triangle a, b, p: positive
+// when p is left of the line from a to b, negative when right and zero
+// when the three points are collinear.
+func cross(a, b, p Point) float64 {
+	return (b.X-a.X)*(p.Y-a.Y) - (p.X-a.X)*(b.Y-a.Y)
+}
+
+// onSegment reports whether p lies on the closed segment from a to b.
+func onSegment(p, a, b Point) bool {
+	if cross(a, b, p) != 0 {
+		return false
+	}
+	return min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) &&
+		min(a.Y, b.Y) <= p.Y && p.Y <= max(a.Y, b.Y)
+}
@@ -0,0 +1,97 @@
+package polygon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolygonLocate(t *testing.T) {
+	square := Polygon{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+
+	// A "C" shape opening to the right.
+	concave := Polygon{{0, 0}, {4, 0}, {4, 1}, {1, 1}, {1, 3}, {4, 3}, {4, 4}, {0, 4}}
+
+	// Square with extra vertices in the middle of its edges.
+	collinear := Polygon{{0, 0}, {2, 0}, {4, 0}, {4, 2}, {4, 4}, {2, 4}, {0, 4}, {0, 2}}
+
+	// Two squares joined at the vertex (2, 2).
+	touching := Polygon{{0, 0}, {2, 0}, {2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}, {0, 2}}
+
+	// Ring that traces its outline twice, covering the inside twice.
+	doubled := Polygon{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}, {4, 0}, {4, 4}, {0, 4}}
+
+	tests := []struct {
+		name     string
+		polygon  Polygon
+		point    Point
+		expected Location
+	}{
+		{name: "square inside", polygon: square, point: Point{2, 2}, expected: Inside},
+		{name: "square outside", polygon: square, point: Point{5, 2}, expected: Outside},
+		{name: "square on edge", polygon: square, point: Point{4, 2}, expected: OnBoundary},
+		{name: "square on vertex", polygon: square, point: Point{0, 4}, expected: OnBoundary},
+		{name: "square ray through vertex", polygon: square, point: Point{-1, 4}, expected: Outside},
+		{name: "square ray along edge", polygon: square, point: Point{-1, 0}, expected: Outside},
+		{name: "clockwise square inside", polygon: Polygon{{0, 0}, {0, 4}, {4, 4}, {4, 0}}, point: Point{1, 3}, expected: Inside},
+		{name: "concave arm", polygon: concave, point: Point{3, 0.5}, expected: Inside},
+		{name: "concave notch", polygon: concave, point: Point{3, 2}, expected: Outside},
+		{name: "concave spine", polygon: concave, point: Point{0.5, 2}, expected: Inside},
+		{name: "concave ray through reflex vertices", polygon: concave, point: Point{0.5, 1}, expected: Inside},
+		{name: "concave notch edge", polygon: concave, point: Point{2, 3}, expected: OnBoundary},
+		{name: "concave outside level with notch", polygon: concave, point: Point{5, 1}, expected: Outside},
+		{name: "collinear inside", polygon: collinear, point: Point{1, 2}, expected: Inside},
+		{name: "collinear mid-edge vertex", polygon: collinear, point: Point{4, 2}, expected: OnBoundary},
+		{name: "collinear ray through mid-edge vertices", polygon: collinear, point: Point{-1, 2}, expected: Outside},
+		{name: "collinear on edge", polygon: collinear, point: Point{3, 0}, expected: OnBoundary},
+		{name: "touching lower lobe", polygon: touching, point: Point{1, 1}, expected: Inside},
+		{name: "touching upper lobe", polygon: touching, point: Point{3, 3}, expected: Inside},
+		{name: "touching empty quadrant", polygon: touching, point: Point{3, 1}, expected: Outside},
+		{name: "touching other empty quadrant", polygon: touching, point: Point{1, 3}, expected: Outside},
+		{name: "touching shared vertex", polygon: touching, point: Point{2, 2}, expected: OnBoundary},
+		{name: "doubled ring inside", polygon: doubled, point: Point{2, 2}, expected: Inside},
+		{name: "duplicate vertex", polygon: Polygon{{0, 0}, {4, 0}, {4, 0}, {4, 4}, {0, 4}}, point: Point{2, 2}, expected: Inside},
+		{name: "degenerate polygon on segment", polygon: Polygon{{0, 0}, {2, 2}}, point: Point{1, 1}, expected: OnBoundary},
+		{name: "empty polygon", polygon: Polygon{}, point: Point{0, 0}, expected: Outside},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.polygon.Locate(tt.point))
+			require.Equal(t, tt.expected != Outside, tt.polygon.Contains(tt.point))
+		})
+	}
+}
+
+func TestPolygonContainsGrid(t *testing.T) {
+	// Star-shaped concave polygon checked against a brute-force even-odd
+	// count, which agrees with the winding rule for simple polygons.
+	star := Polygon{{0, 5}, {2, 2}, {5, 2}, {3, 0}, {4, -4}, {0, -2}, {-4, -4}, {-3, 0}, {-5, 2}, {-2, 2}}
+
+	for x := -6.0; x <= 6; x += 0.37 {
+		for y := -6.0; y <= 6; y += 0.41 {
+			p := Point{x, y}
+			if star.Locate(p) == OnBoundary {
+				continue
+			}
+			require.Equal(t, evenOdd(star, p), star.Contains(p), "point %v", p)
+		}
+	}
+}
+
+func evenOdd(pg Polygon, p Point) bool {
+	inside := false
+	for i, j := 0, len(pg)-1; i < len(pg); j, i = i, i+1 {
+		a, b := pg[i], pg[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func TestLocationString(t *testing.T) {
+	require.Equal(t, "inside", Inside.String())
+	require.Equal(t, "outside", Outside.String())
+	require.Equal(t, "on boundary", OnBoundary.String())
+}
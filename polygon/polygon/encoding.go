@@ -0,0 +1,36 @@
+package polygon
+
+import "fmt"
+
+// SyntaxError reports where WKT, WKB or GeoJSON input could not be decoded.
+// Offset counts bytes from the start of the input.
+type SyntaxError struct {
+	Format string
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid %s at offset %d: %s", e.Format, e.Offset, e.Msg)
+}
+
+// openRing checks a ring as the formats store it, closed by repeating the
+// first position at the end, and returns it without the repeat. A problem
+// is described by the returned message.
+func openRing(ring Polygon) (Polygon, string) {
+	if len(ring) < 4 {
+		return nil, fmt.Sprintf("ring needs at least 4 positions, got %d", len(ring))
+	}
+	if first, last := ring[0], ring[len(ring)-1]; first != last {
+		return nil, fmt.Sprintf("ring is not closed: it starts at %v and ends at %v", first, last)
+	}
+	return ring[:len(ring)-1], ""
+}
+
+// closeRing returns ring with its first vertex repeated at the end.
+func closeRing(ring Polygon) Polygon {
+	if len(ring) == 0 {
+		return ring
+	}
+	return append(ring[:len(ring):len(ring)], ring[0])
+}
@@ -0,0 +1,126 @@
+package polygon
+
+import (
+	"fmt"
+	"math"
+
+	"point/point"
+)
+
+var (
+	wgs84E2 = point.WGS84Flattening * (2 - point.WGS84Flattening)
+	wgs84E  = math.Sqrt(wgs84E2)
+
+	// authalicQPole is authalicQ at the poles. The authalic sphere, whose
+	// radius squared is the semi-major axis squared times authalicQPole / 2,
+	// has the same surface area as the ellipsoid.
+	authalicQPole   = authalicQ(1)
+	authalicRadius2 = point.WGS84SemiMajorAxisKm * point.WGS84SemiMajorAxisKm * authalicQPole / 2
+)
+
+// GeoPolygon is a ring of points on the WGS-84 ellipsoid joined by the
+// shortest paths between them. As with Polygon the ring is implicitly
+// closed. Edges spanning exactly 180° of longitude are ambiguous.
+type GeoPolygon []point.Point
+
+// NewGeoPolygon copies pts, checking each coordinate as point.New does.
+func NewGeoPolygon(pts []point.Point) (GeoPolygon, error) {
+	if len(pts) < 3 {
+		return nil, ErrTooFewVertices
+	}
+
+	result := make(GeoPolygon, len(pts))
+	for i, p := range pts {
+		q, err := point.New(p.Latitude, p.Longitude)
+		if err != nil {
+			return nil, fmt.Errorf("vertex %d: %w", i, err)
+		}
+		result[i] = q
+	}
+	return result, nil
+}
+
+// Area returns the area in square kilometres of the smaller of the two
+// regions the ring divides the globe into, so the orientation of the ring
+// does not matter and rings around a pole are handled.
+//
+// The area is the spherical excess on the authalic sphere, to which
+// latitudes are mapped preserving area. Edges are great circles on that
+// sphere rather than geodesics on the ellipsoid; regions bounded by
+// meridians and the equator come out exact and others differ by far less
+// than the flattening.
+func (g GeoPolygon) Area() float64 {
+	n := len(g)
+	if n < 3 {
+		return 0
+	}
+
+	// Each edge contributes the signed excess of the quadrilateral between
+	// it and the equator.
+	excess, sweep := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		p, q := g[i], g[(i+1)%n]
+		dLng := point.ToRadians(point.NormalizeLongitude(q.Longitude - p.Longitude))
+		t1 := math.Tan(authalicLatitude(p.Latitude) / 2)
+		t2 := math.Tan(authalicLatitude(q.Latitude) / 2)
+		excess += 2 * math.Atan2(math.Tan(dLng/2)*(t1+t2), 1+t1*t2)
+		sweep += dLng
+	}
+
+	// A ring around a pole sweeps a full turn of longitude, and the
+	// quadrilaterals then measure the region on the equator's side of it.
+	turns := math.Round(sweep / (2 * math.Pi))
+	area := math.Abs(2*math.Pi*turns - excess)
+	area = math.Min(area, 4*math.Pi-area)
+
+	return area * authalicRadius2
+}
+
+// Perimeter returns the length of the ring in kilometres, measuring each
+// edge on the ellipsoid with point.VincentyDistance.
+func (g GeoPolygon) Perimeter() float64 {
+	n := len(g)
+	if n < 2 {
+		return 0
+	}
+
+	per := 0.0
+	for i := 0; i < n; i++ {
+		per += point.VincentyDistance(g[i], g[(i+1)%n])
+	}
+	return per
+}
+
+// Planar returns g as a Polygon with longitudes as X and latitudes as Y,
+// for the operations that treat edges as straight lines in those
+// coordinates, such as Locate and Validate.
+func (g GeoPolygon) Planar() Polygon {
+	result := make(Polygon, len(g))
+	for i, p := range g {
+		result[i] = Point{X: p.Longitude, Y: p.Latitude}
+	}
+	return result
+}
+
+// Geo returns pg as a GeoPolygon, reading X as the longitude and Y as the
+// latitude.
+func (pg Polygon) Geo() GeoPolygon {
+	result := make(GeoPolygon, len(pg))
+	for i, p := range pg {
+		result[i] = point.Point{Latitude: p.Y, Longitude: p.X}
+	}
+	return result
+}
+
+// authalicLatitude returns in radians the latitude on the authalic sphere
+// enclosing the same area towards the equator as latitude does, in
+// degrees, on the ellipsoid.
+func authalicLatitude(latitude float64) float64 {
+	q := authalicQ(math.Sin(point.ToRadians(latitude)))
+	return math.Asin(math.Max(-1, math.Min(1, q/authalicQPole)))
+}
+
+func authalicQ(sinLat float64) float64 {
+	es := wgs84E * sinLat
+	return (1 - wgs84E2) * (sinLat/(1-es*es) + math.Atanh(es)/wgs84E)
+}
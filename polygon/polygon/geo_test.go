@@ -0,0 +1,153 @@
+package polygon
+
+import (
+	"math"
+	"testing"
+
+	"point/point"
+
+	"github.com/stretchr/testify/require"
+)
+
+// wgs84AreaKm2 is the surface area of the WGS-84 ellipsoid.
+const wgs84AreaKm2 = 510065621.718
+
+func geo(coords ...[2]float64) GeoPolygon {
+	result := make(GeoPolygon, len(coords))
+	for i, c := range coords {
+		result[i] = point.Point{Latitude: c[0], Longitude: c[1]}
+	}
+	return result
+}
+
+// parallel returns a ring along the given latitude with a vertex every
+// degree of longitude, running east.
+func parallel(latitude float64) GeoPolygon {
+	var result GeoPolygon
+	for lng := -180.0; lng < 180; lng++ {
+		result = append(result, point.Point{Latitude: latitude, Longitude: lng})
+	}
+	return result
+}
+
+// capArea returns the area of the ellipsoid beyond the given latitude.
+func capArea(latitude float64) float64 {
+	return 2 * math.Pi * authalicRadius2 * (1 - math.Sin(authalicLatitude(math.Abs(latitude))))
+}
+
+func TestGeoPolygonArea(t *testing.T) {
+	tests := []struct {
+		name     string
+		polygon  GeoPolygon
+		expected float64
+		relDelta float64
+	}{
+		{
+			name:     "octant",
+			polygon:  geo([2]float64{0, 0}, [2]float64{0, 90}, [2]float64{90, 0}),
+			expected: wgs84AreaKm2 / 8,
+			relDelta: 1e-9,
+		},
+		{
+			name:     "lune between meridians",
+			polygon:  geo([2]float64{-90, 0}, [2]float64{0, 0}, [2]float64{90, 0}, [2]float64{0, 90}),
+			expected: wgs84AreaKm2 / 4,
+			relDelta: 1e-9,
+		},
+		{
+			name:     "small square on the equator",
+			polygon:  geo([2]float64{0, 0}, [2]float64{0, 0.01}, [2]float64{0.01, 0.01}, [2]float64{0.01, 0}),
+			expected: point.VincentyDistance(point.Point{}, point.Point{Longitude: 0.01}) * point.VincentyDistance(point.Point{}, point.Point{Latitude: 0.01}),
+			relDelta: 1e-5,
+		},
+		{
+			name:     "cap around the north pole",
+			polygon:  parallel(80),
+			expected: capArea(80),
+			relDelta: 1e-4,
+		},
+		{
+			name:     "cap around the south pole",
+			polygon:  parallel(-75),
+			expected: capArea(-75),
+			relDelta: 1e-4,
+		},
+		{
+			name:     "cap of nearly a hemisphere",
+			polygon:  parallel(10),
+			expected: capArea(10),
+			relDelta: 1e-4,
+		},
+		{
+			name:     "too few vertices",
+			polygon:  geo([2]float64{0, 0}, [2]float64{1, 1}),
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			area := tt.polygon.Area()
+			require.InDelta(t, tt.expected, area, tt.expected*tt.relDelta)
+			require.InDelta(t, area, tt.polygon.Planar().Reverse().Geo().Area(), area*1e-12)
+		})
+	}
+}
+
+func TestGeoPolygonAreaAcrossAntimeridian(t *testing.T) {
+	// The same 2° square at 40°N, once centred on the prime meridian and
+	// once on the antimeridian.
+	prime := geo([2]float64{39, -1}, [2]float64{39, 1}, [2]float64{41, 1}, [2]float64{41, -1})
+	anti := geo([2]float64{39, 179}, [2]float64{39, -179}, [2]float64{41, -179}, [2]float64{41, 179})
+
+	require.InDelta(t, prime.Area(), anti.Area(), prime.Area()*1e-9)
+
+	// The planar area in square degrees is a poor measure; a degree of
+	// longitude at 40°N is only about 85 km.
+	require.InDelta(t, 4, prime.Planar().Area(), 1e-12)
+	require.InDelta(t, 2*85.4*2*111.0, prime.Area(), 100)
+}
+
+func TestGeoPolygonPerimeter(t *testing.T) {
+	const (
+		equatorQuadrantKm  = point.WGS84SemiMajorAxisKm * math.Pi / 2
+		meridianQuadrantKm = 10001.965729
+	)
+
+	octant := geo([2]float64{0, 0}, [2]float64{0, 90}, [2]float64{90, 0})
+	require.InDelta(t, equatorQuadrantKm+2*meridianQuadrantKm, octant.Perimeter(), 1e-3)
+
+	square := geo([2]float64{0, 0}, [2]float64{0, 1}, [2]float64{1, 1}, [2]float64{1, 0})
+	expected := 0.0
+	for i := range square {
+		expected += point.VincentyDistance(square[i], square[(i+1)%len(square)])
+	}
+	require.InDelta(t, expected, square.Perimeter(), 1e-9)
+
+	require.Zero(t, geo([2]float64{1, 1}).Perimeter())
+}
+
+func TestNewGeoPolygon(t *testing.T) {
+	pts := []point.Point{{Latitude: 1, Longitude: 2}, {Latitude: 3, Longitude: 4}, {Latitude: 5, Longitude: 7}}
+	g, err := NewGeoPolygon(pts)
+	require.NoError(t, err)
+	require.Equal(t, GeoPolygon(pts), g)
+
+	pts[0].Latitude = 10
+	require.Equal(t, 1.0, g[0].Latitude)
+
+	_, err = NewGeoPolygon(pts[:2])
+	require.ErrorIs(t, err, ErrTooFewVertices)
+
+	_, err = NewGeoPolygon([]point.Point{{Latitude: 1, Longitude: 2}, {Latitude: 91, Longitude: 4}, {Latitude: 5, Longitude: 7}})
+	require.ErrorContains(t, err, "vertex 1: latitude")
+}
+
+func TestGeoPolygonPlanar(t *testing.T) {
+	g := geo([2]float64{10, 20}, [2]float64{10, 21}, [2]float64{11, 21})
+	pg := g.Planar()
+
+	require.Equal(t, Polygon{{20, 10}, {21, 10}, {21, 11}}, pg)
+	require.Equal(t, g, pg.Geo())
+	require.True(t, pg.Contains(Point{X: 20.9, Y: 10.5}))
+}
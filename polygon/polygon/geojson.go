@@ -0,0 +1,310 @@
+package polygon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GeoJSONType is the type of a GeoJSON geometry holding polygons.
+type GeoJSONType string
+
+const (
+	GeoJSONPolygon      GeoJSONType = "Polygon"
+	GeoJSONMultiPolygon GeoJSONType = "MultiPolygon"
+)
+
+type geoJSONGeometry struct {
+	Type        GeoJSONType `json:"type"`
+	Coordinates any         `json:"coordinates"`
+}
+
+// WriteGeoJSON encodes mp as a geometry of the given type. As RFC 7946
+// requires, rings are closed and written with outer rings
+// counter-clockwise and holes clockwise. A Polygon takes exactly one part.
+func WriteGeoJSON(w io.Writer, typ GeoJSONType, mp MultiPolygon) error {
+	parts := make([][][][2]float64, len(mp))
+	for i, p := range mp.Orient() {
+		for _, ring := range append([]Polygon{p.Outer}, p.Holes...) {
+			var positions [][2]float64
+			for _, pt := range closeRing(ring) {
+				positions = append(positions, [2]float64{pt.X, pt.Y})
+			}
+			parts[i] = append(parts[i], positions)
+		}
+	}
+
+	geometry := geoJSONGeometry{Type: typ, Coordinates: parts}
+	switch typ {
+	case GeoJSONPolygon:
+		if len(mp) != 1 {
+			return fmt.Errorf("GeoJSON Polygon needs exactly 1 part, got %d", len(mp))
+		}
+		geometry.Coordinates = parts[0]
+	case GeoJSONMultiPolygon:
+	default:
+		return fmt.Errorf("unsupported GeoJSON type %q, expected Polygon or MultiPolygon", typ)
+	}
+
+	return json.NewEncoder(w).Encode(geometry)
+}
+
+// ReadGeoJSON decodes a Polygon or MultiPolygon geometry, bare or wrapped
+// in a Feature; a Polygon gives a single part. Positions are [x, y], that
+// is [longitude, latitude] for geographic data, with an optional altitude,
+// which is ignored. Rings must be closed, and the closing position is
+// dropped; their orientation is kept as it is. Errors in the input are
+// *SyntaxError values locating the problem.
+func ReadGeoJSON(r io.Reader) (GeoJSONType, MultiPolygon, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	obj, err := decodeJSON(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	typ, err := obj.typeName()
+	if err != nil {
+		return "", nil, err
+	}
+	if typ == "Feature" {
+		geometry := obj.object["geometry"]
+		if geometry == nil || geometry.token == nil && geometry.delim == 0 {
+			return "", nil, obj.errorf("GeoJSON feature has no geometry")
+		}
+		obj = geometry
+		if typ, err = obj.typeName(); err != nil {
+			return "", nil, err
+		}
+	}
+
+	coordinates := obj.object["coordinates"]
+	if coordinates == nil {
+		return "", nil, obj.errorf("GeoJSON %s has no coordinates", typ)
+	}
+
+	switch GeoJSONType(typ) {
+	case GeoJSONPolygon:
+		part, err := geoJSONPolygon(coordinates)
+		if err != nil {
+			return "", nil, err
+		}
+		return GeoJSONPolygon, MultiPolygon{part}, nil
+
+	case GeoJSONMultiPolygon:
+		if coordinates.delim != '[' {
+			return "", nil, coordinates.errorf("expected an array of polygons, found %s", coordinates.kind())
+		}
+		var result MultiPolygon
+		for _, v := range coordinates.array {
+			part, err := geoJSONPolygon(v)
+			if err != nil {
+				return "", nil, err
+			}
+			result = append(result, part)
+		}
+		return GeoJSONMultiPolygon, result, nil
+
+	default:
+		return "", nil, obj.object["type"].errorf("unsupported GeoJSON type %q, expected Polygon or MultiPolygon", typ)
+	}
+}
+
+func geoJSONPolygon(v *jsonValue) (WithHoles, error) {
+	if v.delim != '[' {
+		return WithHoles{}, v.errorf("expected an array of rings, found %s", v.kind())
+	}
+	if len(v.array) == 0 {
+		return WithHoles{}, v.errorf("polygon has no rings")
+	}
+
+	var result WithHoles
+	for i, r := range v.array {
+		ring, err := geoJSONRing(r)
+		if err != nil {
+			return WithHoles{}, err
+		}
+		if i == 0 {
+			result.Outer = ring
+		} else {
+			result.Holes = append(result.Holes, ring)
+		}
+	}
+	return result, nil
+}
+
+func geoJSONRing(v *jsonValue) (Polygon, error) {
+	if v.delim != '[' {
+		return nil, v.errorf("expected an array of positions, found %s", v.kind())
+	}
+
+	ring := make(Polygon, len(v.array))
+	for i, position := range v.array {
+		if position.delim != '[' {
+			return nil, position.errorf("expected a position, found %s", position.kind())
+		}
+		if n := len(position.array); n < 2 || n > 3 {
+			return nil, position.errorf("position must have 2 or 3 values, got %d", n)
+		}
+
+		var coords [2]float64
+		for j := range coords {
+			c := position.array[j]
+			number, ok := c.token.(json.Number)
+			if !ok {
+				return nil, c.errorf("expected a number, found %s", c.kind())
+			}
+			f, err := number.Float64()
+			if err != nil {
+				return nil, c.errorf("invalid number %s", number)
+			}
+			coords[j] = f
+		}
+		ring[i] = Point{coords[0], coords[1]}
+	}
+
+	ring, msg := openRing(ring)
+	if msg != "" {
+		return nil, v.errorf("%s", msg)
+	}
+	return ring, nil
+}
+
+// jsonValue is a decoded JSON value together with the offset at which it
+// starts, so that errors about its content can say where it is.
+type jsonValue struct {
+	offset int
+	delim  json.Delim // '{' for objects, '[' for arrays, 0 otherwise
+	object map[string]*jsonValue
+	array  []*jsonValue
+	token  json.Token // json.Number, string or bool; nil for null
+}
+
+func (v *jsonValue) errorf(format string, args ...any) error {
+	return &SyntaxError{Format: "GeoJSON", Offset: v.offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (v *jsonValue) kind() string {
+	switch t := v.token.(type) {
+	case json.Number:
+		return "a number"
+	case string:
+		return "a string"
+	case bool:
+		return fmt.Sprint(t)
+	}
+	switch v.delim {
+	case '{':
+		return "an object"
+	case '[':
+		return "an array"
+	default:
+		return "null"
+	}
+}
+
+// typeName returns the "type" member of an object.
+func (v *jsonValue) typeName() (string, error) {
+	if v.delim != '{' {
+		return "", v.errorf("expected a GeoJSON object, found %s", v.kind())
+	}
+	typ := v.object["type"]
+	if typ == nil {
+		return "", v.errorf("GeoJSON object has no type")
+	}
+	name, ok := typ.token.(string)
+	if !ok {
+		return "", typ.errorf("expected the type as a string, found %s", typ.kind())
+	}
+	return name, nil
+}
+
+func decodeJSON(data []byte) (*jsonValue, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	v, err := readJSON(dec, data)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := int(dec.InputOffset())
+	if _, err := dec.Token(); err != io.EOF {
+		if err != nil {
+			return nil, jsonError(err, data)
+		}
+		return nil, &SyntaxError{Format: "GeoJSON", Offset: skipJSONSpace(data, offset), Msg: "unexpected data after the end"}
+	}
+	return v, nil
+}
+
+func readJSON(dec *json.Decoder, data []byte) (*jsonValue, error) {
+	offset := skipJSONSpace(data, int(dec.InputOffset()))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, jsonError(err, data)
+	}
+
+	v := &jsonValue{offset: offset}
+	switch tok {
+	case json.Delim('{'):
+		v.delim = '{'
+		v.object = make(map[string]*jsonValue)
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, jsonError(err, data)
+			}
+			member, err := readJSON(dec, data)
+			if err != nil {
+				return nil, err
+			}
+			v.object[key.(string)] = member
+		}
+	case json.Delim('['):
+		v.delim = '['
+		for dec.More() {
+			elem, err := readJSON(dec, data)
+			if err != nil {
+				return nil, err
+			}
+			v.array = append(v.array, elem)
+		}
+	default:
+		v.token = tok
+		return v, nil
+	}
+
+	// The closing delimiter.
+	if _, err := dec.Token(); err != nil {
+		return nil, jsonError(err, data)
+	}
+	return v, nil
+}
+
+// skipJSONSpace returns the offset of the first token at or after offset,
+// passing over the separators the decoder leaves before it.
+func skipJSONSpace(data []byte, offset int) int {
+	for offset < len(data) && strings.IndexByte(" \t\r\n,:", data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func jsonError(err error, data []byte) error {
+	var syntax *json.SyntaxError
+	switch {
+	case errors.As(err, &syntax):
+		return &SyntaxError{Format: "GeoJSON", Offset: int(syntax.Offset), Msg: syntax.Error()}
+	case err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF):
+		return &SyntaxError{Format: "GeoJSON", Offset: len(data), Msg: "unexpected end of JSON input"}
+	default:
+		return err
+	}
+}
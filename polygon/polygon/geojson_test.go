@@ -0,0 +1,176 @@
+package polygon
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteGeoJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      GeoJSONType
+		mp       MultiPolygon
+		expected string
+	}{
+		{
+			name:     "polygon",
+			typ:      GeoJSONPolygon,
+			mp:       MultiPolygon{{Outer: unitTriangle}},
+			expected: `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+		},
+		{
+			name:     "clockwise polygon is reoriented",
+			typ:      GeoJSONPolygon,
+			mp:       MultiPolygon{{Outer: unitTriangle.Reverse()}},
+			expected: `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+		},
+		{
+			name: "multi-polygon",
+			typ:  GeoJSONMultiPolygon,
+			mp:   encodingFixture,
+			expected: `{"type":"MultiPolygon","coordinates":[` +
+				`[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[2,4],[4,4],[4,2],[2,2]]],` +
+				`[[[20,0],[22,-3.25],[25.5,0],[20,0]]]]}`,
+		},
+		{
+			name:     "empty multi-polygon",
+			typ:      GeoJSONMultiPolygon,
+			mp:       nil,
+			expected: `{"type":"MultiPolygon","coordinates":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteGeoJSON(&buf, tt.typ, tt.mp))
+			require.JSONEq(t, tt.expected, buf.String())
+		})
+	}
+
+	var buf bytes.Buffer
+	require.Error(t, WriteGeoJSON(&buf, GeoJSONPolygon, encodingFixture))
+	require.Error(t, WriteGeoJSON(&buf, GeoJSONPolygon, nil))
+	require.Error(t, WriteGeoJSON(&buf, "LineString", encodingFixture))
+}
+
+func TestReadGeoJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		typ      GeoJSONType
+		expected MultiPolygon
+	}{
+		{
+			name:     "polygon",
+			input:    `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}`,
+			typ:      GeoJSONPolygon,
+			expected: MultiPolygon{{Outer: unitTriangle}},
+		},
+		{
+			name:     "orientation is kept",
+			input:    `{"type": "Polygon", "coordinates": [[[1, 1], [1, 0], [0, 0], [1, 1]]]}`,
+			typ:      GeoJSONPolygon,
+			expected: MultiPolygon{{Outer: unitTriangle.Reverse()}},
+		},
+		{
+			name: "feature with multi-polygon and altitudes",
+			input: `{"type": "Feature", "properties": {"name": "zone", "tags": [1, {"a": null}]}, "geometry": {
+				"coordinates": [[[[0, 0, 1], [1, 0, 1], [1, 1, 2], [0, 0, 1]]], [[[5, 5], [6, 5], [6, 6], [5, 5]]]],
+				"type": "MultiPolygon"}}`,
+			typ:      GeoJSONMultiPolygon,
+			expected: MultiPolygon{{Outer: unitTriangle}, {Outer: Polygon{{5, 5}, {6, 5}, {6, 6}}}},
+		},
+		{
+			name:     "round trip",
+			input:    geoJSONString(t, GeoJSONMultiPolygon, encodingFixture),
+			typ:      GeoJSONMultiPolygon,
+			expected: encodingFixture,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ, mp, err := ReadGeoJSON(strings.NewReader(tt.input))
+			require.NoError(t, err)
+			require.Equal(t, tt.typ, typ)
+			require.Equal(t, tt.expected, mp)
+		})
+	}
+}
+
+func geoJSONString(t testing.TB, typ GeoJSONType, mp MultiPolygon) string {
+	var buf bytes.Buffer
+	require.NoError(t, WriteGeoJSON(&buf, typ, mp))
+	return buf.String()
+}
+
+func TestReadGeoJSONErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		offset int
+		msg    string
+	}{
+		{name: "empty", input: "", offset: 0, msg: "unexpected end of JSON input"},
+		{name: "truncated", input: `{"type": "Polygon"`, offset: 18, msg: "unexpected end of JSON input"},
+		{name: "invalid JSON", input: `{"type": Polygon}`, offset: 10, msg: "invalid character 'P'"},
+		{name: "not an object", input: ` [1, 2]`, offset: 1, msg: "expected a GeoJSON object, found an array"},
+		{name: "no type", input: `{"coordinates": []}`, offset: 0, msg: "GeoJSON object has no type"},
+		{name: "type not a string", input: `{"type": 5}`, offset: 9, msg: "expected the type as a string, found a number"},
+		{name: "unsupported type", input: `{"type": "LineString", "coordinates": []}`, offset: 9, msg: `unsupported GeoJSON type "LineString"`},
+		{name: "feature without geometry", input: `{"type": "Feature", "geometry": null}`, offset: 0, msg: "GeoJSON feature has no geometry"},
+		{name: "no coordinates", input: `{"type": "Polygon"}`, offset: 0, msg: "GeoJSON Polygon has no coordinates"},
+		{name: "no rings", input: `{"type": "Polygon", "coordinates": []}`, offset: 35, msg: "polygon has no rings"},
+		{name: "ring not an array", input: `{"type": "Polygon", "coordinates": [{}]}`, offset: 36, msg: "expected an array of positions, found an object"},
+		{name: "unclosed ring", input: `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1]]]}`, offset: 36, msg: "ring is not closed"},
+		{name: "short ring", input: `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [0, 0]]]}`, offset: 36, msg: "ring needs at least 4 positions, got 3"},
+		{name: "short position", input: `{"type": "Polygon", "coordinates": [[[0, 0], [1], [1, 1], [0, 0]]]}`, offset: 45, msg: "position must have 2 or 3 values, got 1"},
+		{name: "string coordinate", input: `{"type": "Polygon", "coordinates": [[[0, 0], [1, "0"], [1, 1], [0, 0]]]}`, offset: 49, msg: "expected a number, found a string"},
+		{name: "number out of range", input: `{"type": "Polygon", "coordinates": [[[0, 0], [1, 1e999], [1, 1], [0, 0]]]}`, offset: 49, msg: "invalid number 1e999"},
+		{name: "multi-polygon of rings", input: `{"type": "MultiPolygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}`, offset: 43, msg: "expected a position, found a number"},
+		{name: "trailing data", input: `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]} {}`, offset: 71, msg: "unexpected data after the end"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ReadGeoJSON(strings.NewReader(tt.input))
+			var syntax *SyntaxError
+			require.ErrorAs(t, err, &syntax)
+			require.Equal(t, "GeoJSON", syntax.Format)
+			require.Equal(t, tt.offset, syntax.Offset)
+			require.Contains(t, syntax.Msg, tt.msg)
+		})
+	}
+}
+
+func FuzzGeoJSON(f *testing.F) {
+	f.Add(geoJSONString(f, GeoJSONMultiPolygon, encodingFixture))
+	f.Add(geoJSONString(f, GeoJSONPolygon, encodingFixture[:1]))
+	f.Add(`{"type": "Feature", "geometry": {"type": "Polygon", "coordinates": [[[0, 0, 1], [1, 0, 1], [1, 1, 2], [0, 0, 1]]]}}`)
+	f.Add(`{"type": "Polygon", "coordinates": [[[1e300, -1e-300], [0, 0], [0.5, -0.5], [1e300, -1e-300]]]}`)
+
+	f.Fuzz(func(t *testing.T, s string) {
+		typ, mp, err := ReadGeoJSON(strings.NewReader(s))
+		if err != nil {
+			var syntax *SyntaxError
+			if !errors.As(err, &syntax) || syntax.Offset < 0 || syntax.Offset > len(s) {
+				t.Fatalf("unexpected error %#v", err)
+			}
+			return
+		}
+
+		// Writing orients the rings; everything else comes back as read.
+		againTyp, again, err := ReadGeoJSON(strings.NewReader(geoJSONString(t, typ, mp)))
+		require.NoError(t, err)
+		require.Equal(t, typ, againTyp)
+		require.Equal(t, len(mp), len(again))
+		if len(mp) > 0 {
+			require.Equal(t, mp.Orient(), again)
+		}
+	})
+}
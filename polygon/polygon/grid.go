@@ -0,0 +1,113 @@
+package polygon
+
+import "math"
+
+// edgeGrid buckets the edges of a ring by the cells of a uniform grid they
+// pass through, so that finding the edges near a segment does not take a
+// scan of the whole ring. Edges are pairs of vertex indices; entries for
+// edges that no longer exist are left for the caller to skip.
+type edgeGrid struct {
+	pg         Polygon
+	min        Point
+	cellW      float64
+	cellH      float64
+	cols, rows int
+	cells      [][][2]int
+}
+
+// newEdgeGrid covers the bounding box of pg with about len(pg) cells,
+// close to square so that a long thin ring does not crowd its edges into
+// a few rows or columns.
+func newEdgeGrid(pg Polygon) *edgeGrid {
+	lo, hi := pg[0], pg[0]
+	for _, p := range pg {
+		lo = Point{math.Min(lo.X, p.X), math.Min(lo.Y, p.Y)}
+		hi = Point{math.Max(hi.X, p.X), math.Max(hi.Y, p.Y)}
+	}
+
+	n := len(pg)
+	width, height := hi.X-lo.X, hi.Y-lo.Y
+	side := int(math.Ceil(math.Sqrt(float64(n))))
+	cols, rows := side, side
+	if width > 0 && height > 0 {
+		size := math.Sqrt(width * height / float64(n))
+		cols = max(1, min(n, int(math.Ceil(width/size))))
+		rows = max(1, min(n, int(math.Ceil(height/size))))
+	}
+
+	g := &edgeGrid{
+		pg:    pg,
+		min:   lo,
+		cellW: width / float64(cols),
+		cellH: height / float64(rows),
+		cols:  cols,
+		rows:  rows,
+	}
+	if g.cellW == 0 {
+		g.cellW, g.cols = 1, 1
+	}
+	if g.cellH == 0 {
+		g.cellH, g.rows = 1, 1
+	}
+	g.cells = make([][][2]int, g.cols*g.rows)
+	return g
+}
+
+// insert adds the edge from vertex i to vertex j.
+func (g *edgeGrid) insert(i, j int) {
+	g.cover(g.pg[i], g.pg[j], func(cell int) bool {
+		g.cells[cell] = append(g.cells[cell], [2]int{i, j})
+		return true
+	})
+}
+
+// visit calls fn with the edges in the cells the segment ab passes
+// through, an edge once per cell it shares with ab, until fn returns
+// false.
+func (g *edgeGrid) visit(a, b Point, fn func(e [2]int) bool) {
+	g.cover(a, b, func(cell int) bool {
+		for _, e := range g.cells[cell] {
+			if !fn(e) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// cover calls fn with the cells the segment ab passes through, padded
+// against rounding, column by column until fn returns false.
+func (g *edgeGrid) cover(a, b Point, fn func(cell int) bool) {
+	if a.X > b.X {
+		a, b = b, a
+	}
+
+	for col := g.col(a.X); col <= g.col(b.X); col++ {
+		// The part of ab within the column.
+		x0 := math.Max(a.X, g.min.X+float64(col)*g.cellW)
+		x1 := math.Min(b.X, g.min.X+float64(col+1)*g.cellW)
+		y0, y1 := a.Y, b.Y
+		if a.X != b.X {
+			y0 = a.Y + (x0-a.X)/(b.X-a.X)*(b.Y-a.Y)
+			y1 = a.Y + (x1-a.X)/(b.X-a.X)*(b.Y-a.Y)
+		}
+		if y0 > y1 {
+			y0, y1 = y1, y0
+		}
+
+		pad := 1e-9 * g.cellH
+		for row := g.row(y0 - pad); row <= g.row(y1+pad); row++ {
+			if !fn(row*g.cols + col) {
+				return
+			}
+		}
+	}
+}
+
+func (g *edgeGrid) col(x float64) int {
+	return max(0, min(g.cols-1, int((x-g.min.X)/g.cellW)))
+}
+
+func (g *edgeGrid) row(y float64) int {
+	return max(0, min(g.rows-1, int((y-g.min.Y)/g.cellH)))
+}
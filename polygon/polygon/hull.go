@@ -0,0 +1,333 @@
+package polygon
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+var ErrNoPoints = errors.New("no points given")
+
+// Circle is a circle given by its centre and radius.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// Contains reports whether p lies inside the circle or on it, allowing for
+// rounding in the radius.
+func (c Circle) Contains(p Point) bool {
+	return dist(c.Center, p) <= c.Radius*(1+1e-12)+1e-12
+}
+
+// ConvexHull returns the convex hull of pts counter-clockwise, starting at
+// the lowest then leftmost point, using Andrew's monotone chain. Duplicate
+// points and points on the hull's edges are left out, so collinear input
+// yields only its two end points and a single distinct point yields one.
+func ConvexHull(pts []Point) Polygon {
+	sorted := slices.Clone(pts)
+	slices.SortFunc(sorted, func(a, b Point) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+	sorted = slices.Compact(sorted)
+
+	if len(sorted) < 3 {
+		return Polygon(sorted)
+	}
+
+	hull := make(Polygon, 0, 2*len(sorted))
+
+	// Lower hull left to right, then upper hull right to left, popping
+	// every point that does not make a strict left turn.
+	for _, p := range sorted {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		p := sorted[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	hull = hull[:len(hull)-1]
+
+	// Start at the lowest point, leftmost among equals.
+	start := 0
+	for i, p := range hull {
+		if p.Y < hull[start].Y || (p.Y == hull[start].Y && p.X < hull[start].X) {
+			start = i
+		}
+	}
+	return append(hull[start:len(hull):len(hull)], hull[:start]...)
+}
+
+// ConcaveHull returns a simple polygon around pts that follows concave
+// parts of the point set, by digging into the convex hull (Park and Oh,
+// 2012). An edge is replaced by two edges through the nearest point inside
+// when the edge is more than concavity times longer than the shorter new
+// edge; larger values give a shape closer to the convex hull, and around 2
+// is a common choice. Every input point lies inside the result or on it.
+// Collinear input yields the same degenerate result as ConvexHull.
+func ConcaveHull(pts []Point, concavity float64) Polygon {
+	hull := ConvexHull(pts)
+	if len(hull) < 3 {
+		return hull
+	}
+
+	onHull := make(map[Point]bool, len(hull))
+	for _, p := range hull {
+		onHull[p] = true
+	}
+	var inner []Point
+	for _, p := range pts {
+		if !onHull[p] {
+			onHull[p] = true
+			inner = append(inner, p)
+		}
+	}
+
+	for i := 0; i < len(hull) && len(inner) > 0; {
+		a, b := hull[i], hull[(i+1)%len(hull)]
+
+		k := diggingCandidate(hull, i, inner)
+		if k >= 0 {
+			p := inner[k]
+			if dist(a, b)/math.Min(dist(a, p), dist(b, p)) > concavity && canDig(hull, i, p, inner) {
+				hull = slices.Insert(hull, i+1, p)
+				inner = slices.Delete(inner, k, k+1)
+				continue
+			}
+		}
+		i++
+	}
+
+	return hull
+}
+
+// diggingCandidate returns the index of the inner point nearest to hull
+// edge i that is not nearer to either neighbouring edge, or -1.
+func diggingCandidate(hull Polygon, i int, inner []Point) int {
+	n := len(hull)
+	a, b := hull[i], hull[(i+1)%n]
+	prev := [2]Point{hull[(i+n-1)%n], a}
+	next := [2]Point{b, hull[(i+2)%n]}
+
+	best, bestDist := -1, math.Inf(1)
+	for k, p := range inner {
+		d := segmentDistance(p, a, b)
+		if d >= bestDist || d > segmentDistance(p, prev[0], prev[1]) || d > segmentDistance(p, next[0], next[1]) {
+			continue
+		}
+		best, bestDist = k, d
+	}
+	return best
+}
+
+// canDig reports whether replacing hull edge i by edges through p keeps the
+// hull simple and every other inner point inside it.
+func canDig(hull Polygon, i int, p Point, inner []Point) bool {
+	n := len(hull)
+	a, b := hull[i], hull[(i+1)%n]
+
+	for j := 0; j < n; j++ {
+		if j == i {
+			continue
+		}
+		c, d := hull[j], hull[(j+1)%n]
+		for _, e := range [][2]Point{{a, p}, {p, b}} {
+			if !properlyDisjoint(e[0], e[1], c, d) {
+				return false
+			}
+		}
+	}
+
+	// A point on the edge itself changes the outline but not the area.
+	if cross(a, p, b) == 0 {
+		return true
+	}
+
+	triangle := Polygon{a, p, b}
+	for _, q := range inner {
+		if q != p && triangle.Locate(q) != Outside {
+			return false
+		}
+	}
+	return true
+}
+
+// properlyDisjoint reports whether segments ab and cd meet nowhere except
+// at an endpoint they share.
+func properlyDisjoint(a, b, c, d Point) bool {
+	shared := a == c || a == d || b == c || b == d
+	if !shared {
+		return !segmentsIntersect(a, b, c, d)
+	}
+
+	// Segments sharing an endpoint only overlap if they are collinear and
+	// point the same way from it.
+	if cross(a, b, c) != 0 || cross(a, b, d) != 0 {
+		return true
+	}
+	switch {
+	case a == c:
+		return !sameDirection(a, b, d)
+	case a == d:
+		return !sameDirection(a, b, c)
+	case b == c:
+		return !sameDirection(b, a, d)
+	default:
+		return !sameDirection(b, a, c)
+	}
+}
+
+func sameDirection(o, p, q Point) bool {
+	return (p.X-o.X)*(q.X-o.X)+(p.Y-o.Y)*(q.Y-o.Y) > 0
+}
+
+// segmentDistance returns the distance from p to the segment ab.
+func segmentDistance(p, a, b Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	lengthSq := dx*dx + dy*dy
+	if lengthSq == 0 {
+		return dist(p, a)
+	}
+
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / lengthSq
+	t = math.Max(0, math.Min(1, t))
+	return dist(p, Point{a.X + t*dx, a.Y + t*dy})
+}
+
+// MinAreaRectangle returns the smallest-area rectangle, in any rotation,
+// enclosing pts as four corners counter-clockwise. One side of such a
+// rectangle always lies along a hull edge, so rotating calipers walk the
+// hull once keeping track of the extreme points for each edge. It returns
+// ErrDegenerate when the points are collinear or fewer than three.
+func MinAreaRectangle(pts []Point) (Polygon, error) {
+	hull := ConvexHull(pts)
+	n := len(hull)
+	if n < 3 {
+		return nil, ErrDegenerate
+	}
+
+	at := func(i int) Point { return hull[i%n] }
+	dot := func(u, p Point) float64 { return u.X*p.X + u.Y*p.Y }
+
+	var best Polygon
+	bestArea := math.Inf(1)
+
+	// Extreme hull vertices along the edge, away from it and against it.
+	right, top, left := 1, 1, 1
+	for i := 0; i < n; i++ {
+		a, b := hull[i], at(i+1)
+		length := dist(a, b)
+		u := Point{(b.X - a.X) / length, (b.Y - a.Y) / length}
+		v := Point{-u.Y, u.X}
+
+		right = max(right, i+1)
+		for dot(u, at(right+1)) > dot(u, at(right)) {
+			right++
+		}
+		top = max(top, right)
+		for dot(v, at(top+1)) > dot(v, at(top)) {
+			top++
+		}
+		left = max(left, top)
+		for dot(u, at(left+1)) < dot(u, at(left)) {
+			left++
+		}
+
+		minU := dot(u, Point{at(left).X - a.X, at(left).Y - a.Y})
+		maxU := dot(u, Point{at(right).X - a.X, at(right).Y - a.Y})
+		height := dot(v, Point{at(top).X - a.X, at(top).Y - a.Y})
+
+		if area := (maxU - minU) * height; area < bestArea {
+			corner := func(s, t float64) Point {
+				return Point{a.X + u.X*s + v.X*t, a.Y + u.Y*s + v.Y*t}
+			}
+			bestArea = area
+			best = Polygon{corner(minU, 0), corner(maxU, 0), corner(maxU, height), corner(minU, height)}
+		}
+	}
+
+	return best, nil
+}
+
+// MinEnclosingCircle returns the smallest circle containing pts, using
+// Welzl's algorithm over the points in a shuffled but fixed order, so the
+// expected running time is linear and the result is reproducible.
+func MinEnclosingCircle(pts []Point) (Circle, error) {
+	if len(pts) == 0 {
+		return Circle{}, ErrNoPoints
+	}
+
+	shuffled := slices.Clone(pts)
+	r := rand.New(rand.NewPCG(uint64(len(pts)), 0))
+	r.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	c := Circle{Center: shuffled[0]}
+	for i := 1; i < len(shuffled); i++ {
+		if c.Contains(shuffled[i]) {
+			continue
+		}
+
+		// shuffled[i] lies on the circle of the first i+1 points.
+		c = Circle{Center: shuffled[i]}
+		for j := 0; j < i; j++ {
+			if c.Contains(shuffled[j]) {
+				continue
+			}
+
+			c = circleFrom2(shuffled[i], shuffled[j])
+			for k := 0; k < j; k++ {
+				if !c.Contains(shuffled[k]) {
+					c = circleFrom3(shuffled[i], shuffled[j], shuffled[k])
+				}
+			}
+		}
+	}
+
+	return c, nil
+}
+
+func circleFrom2(a, b Point) Circle {
+	center := Point{(a.X + b.X) / 2, (a.Y + b.Y) / 2}
+	return Circle{Center: center, Radius: dist(a, b) / 2}
+}
+
+// circleFrom3 returns the circumcircle of a, b and c, or for collinear
+// points the circle on the two furthest apart.
+func circleFrom3(a, b, c Point) Circle {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		widest := circleFrom2(a, b)
+		for _, candidate := range []Circle{circleFrom2(a, c), circleFrom2(b, c)} {
+			if candidate.Radius > widest.Radius {
+				widest = candidate
+			}
+		}
+		return widest
+	}
+
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	center := Point{a.X + (cy*b2-by*c2)/d, a.Y + (bx*c2-cx*b2)/d}
+	return Circle{
+		Center: center,
+		Radius: max(dist(center, a), dist(center, b), dist(center, c)),
+	}
+}
@@ -0,0 +1,334 @@
+package polygon
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvexHull(t *testing.T) {
+	tests := []struct {
+		name     string
+		points   []Point
+		expected Polygon
+	}{
+		{
+			name:     "square with interior points",
+			points:   []Point{{1, 1}, {0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 0.5}},
+			expected: Polygon{{0, 0}, {2, 0}, {2, 2}, {0, 2}},
+		},
+		{
+			name:     "collinear points on edges",
+			points:   []Point{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 2}, {1, 2}, {0, 2}, {0, 1}},
+			expected: Polygon{{0, 0}, {2, 0}, {2, 2}, {0, 2}},
+		},
+		{
+			name:     "duplicates",
+			points:   []Point{{0, 0}, {0, 0}, {3, 0}, {3, 0}, {0, 3}, {0, 3}, {1, 1}, {1, 1}},
+			expected: Polygon{{0, 0}, {3, 0}, {0, 3}},
+		},
+		{
+			name:     "starts at lowest then leftmost point",
+			points:   []Point{{5, 5}, {1, -1}, {-3, 2}, {3, -1}},
+			expected: Polygon{{1, -1}, {3, -1}, {5, 5}, {-3, 2}},
+		},
+		{
+			name:     "all collinear",
+			points:   []Point{{2, 2}, {0, 0}, {1, 1}, {3, 3}},
+			expected: Polygon{{0, 0}, {3, 3}},
+		},
+		{
+			name:     "single distinct point",
+			points:   []Point{{1, 1}, {1, 1}},
+			expected: Polygon{{1, 1}},
+		},
+		{
+			name:     "empty",
+			points:   nil,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := slices.Clone(tt.points)
+			require.Equal(t, tt.expected, ConvexHull(tt.points))
+			require.Equal(t, original, tt.points)
+		})
+	}
+}
+
+func TestConvexHullRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+
+	for iter := 0; iter < 100; iter++ {
+		pts := make([]Point, 5+r.IntN(200))
+		for i := range pts {
+			// A coarse grid makes duplicates and collinear points common.
+			pts[i] = Point{float64(r.IntN(20)), float64(r.IntN(20))}
+		}
+
+		hull := ConvexHull(pts)
+		require.NoError(t, hull.Validate())
+		require.Positive(t, hull.SignedArea())
+
+		for i := range hull {
+			a, b, c := hull[i], hull[(i+1)%len(hull)], hull[(i+2)%len(hull)]
+			require.Positive(t, cross(a, b, c), "hull is not strictly convex at %v", b)
+		}
+		for _, p := range pts {
+			require.True(t, hull.Contains(p))
+		}
+	}
+}
+
+// cPoints samples a "C" shaped region on a grid.
+func cPoints() []Point {
+	var pts []Point
+	for x := 0.0; x <= 10; x++ {
+		for y := 0.0; y <= 10; y++ {
+			if x > 3 && y > 3 && y < 7 {
+				continue
+			}
+			pts = append(pts, Point{x, y})
+		}
+	}
+	return pts
+}
+
+func TestConcaveHull(t *testing.T) {
+	pts := cPoints()
+	convex := ConvexHull(pts)
+	concave := ConcaveHull(pts, 2)
+
+	require.NoError(t, concave.Validate())
+	require.Positive(t, concave.SignedArea())
+	for _, p := range pts {
+		require.True(t, concave.Contains(p), "%v is outside the hull", p)
+	}
+
+	// The hull follows the notch: its area is close to the 100 of the full
+	// square less the 3x6 opening cut into it.
+	require.InDelta(t, 100, convex.Area(), 1e-9)
+	require.Less(t, concave.Area(), 90.0)
+	require.Equal(t, Outside, concave.Locate(Point{8, 5}))
+	require.Equal(t, Inside, concave.Locate(Point{2, 5}))
+
+	// Deterministic for the same input.
+	require.Equal(t, concave, ConcaveHull(pts, 2))
+
+	// A large concavity never digs.
+	require.Equal(t, convex, ConcaveHull(pts, 1e9))
+}
+
+func TestConcaveHullEdgeCases(t *testing.T) {
+	require.Equal(t, Polygon{{0, 0}, {3, 3}}, ConcaveHull([]Point{{0, 0}, {1, 1}, {3, 3}}, 2))
+	require.Empty(t, ConcaveHull(nil, 2))
+
+	// Duplicate interior points are used once.
+	pts := []Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {2, 3.5}, {2, 3.5}}
+	hull := ConcaveHull(pts, 1)
+	require.NoError(t, hull.Validate())
+	require.Len(t, hull, 5)
+}
+
+func TestConcaveHullRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+
+	for iter := 0; iter < 50; iter++ {
+		pts := make([]Point, 10+r.IntN(150))
+		for i := range pts {
+			pts[i] = Point{float64(r.IntN(30)), float64(r.IntN(30))}
+		}
+
+		hull := ConcaveHull(pts, 1.5)
+		require.NoError(t, hull.Validate())
+		require.LessOrEqual(t, hull.Area(), ConvexHull(pts).Area()+1e-9)
+		for _, p := range pts {
+			require.True(t, hull.Contains(p), "%v is outside the hull", p)
+		}
+	}
+}
+
+// bruteForceRectangleArea tries every hull edge as a side of the
+// rectangle.
+func bruteForceRectangleArea(pts []Point) float64 {
+	hull := ConvexHull(pts)
+	best := math.Inf(1)
+	for i := range hull {
+		a, b := hull[i], hull[(i+1)%len(hull)]
+		length := dist(a, b)
+		ux, uy := (b.X-a.X)/length, (b.Y-a.Y)/length
+
+		minU, maxU, maxV := math.Inf(1), math.Inf(-1), math.Inf(-1)
+		for _, p := range hull {
+			s := (p.X-a.X)*ux + (p.Y-a.Y)*uy
+			t := -(p.X-a.X)*uy + (p.Y-a.Y)*ux
+			minU, maxU, maxV = math.Min(minU, s), math.Max(maxU, s), math.Max(maxV, t)
+		}
+		best = math.Min(best, (maxU-minU)*maxV)
+	}
+	return best
+}
+
+func TestMinAreaRectangle(t *testing.T) {
+	// A 4x2 rectangle rotated by 30 degrees, with points inside it and
+	// duplicates of its corners.
+	angle := math.Pi / 6
+	rotate := func(x, y float64) Point {
+		return Point{x*math.Cos(angle) - y*math.Sin(angle), x*math.Sin(angle) + y*math.Cos(angle)}
+	}
+	pts := []Point{rotate(0, 0), rotate(4, 0), rotate(4, 2), rotate(0, 2), rotate(1, 1), rotate(3, 0.5), rotate(4, 2), rotate(2, 0)}
+
+	rect, err := MinAreaRectangle(pts)
+	require.NoError(t, err)
+	require.Len(t, rect, 4)
+	require.InDelta(t, 8, rect.Area(), 1e-9)
+	require.Positive(t, rect.SignedArea())
+	require.InDelta(t, 12, rect.Perimeter(), 1e-9)
+
+	// Right angles at every corner.
+	for i := range rect {
+		a, b, c := rect[i], rect[(i+1)%4], rect[(i+2)%4]
+		require.InDelta(t, 0, (b.X-a.X)*(c.X-b.X)+(b.Y-a.Y)*(c.Y-b.Y), 1e-9)
+	}
+
+	triangle, err := MinAreaRectangle([]Point{{0, 0}, {2, 0}, {1, 1}})
+	require.NoError(t, err)
+	require.InDelta(t, 2, triangle.Area(), 1e-12)
+
+	_, err = MinAreaRectangle([]Point{{0, 0}, {1, 1}, {2, 2}, {1, 1}})
+	require.ErrorIs(t, err, ErrDegenerate)
+
+	_, err = MinAreaRectangle(nil)
+	require.ErrorIs(t, err, ErrDegenerate)
+}
+
+func TestMinAreaRectangleRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8))
+
+	for iter := 0; iter < 200; iter++ {
+		pts := make([]Point, 3+r.IntN(60))
+		for i := range pts {
+			pts[i] = Point{r.NormFloat64() * 5, r.NormFloat64() * 2}
+		}
+
+		rect, err := MinAreaRectangle(pts)
+		require.NoError(t, err)
+		require.InDelta(t, bruteForceRectangleArea(pts), rect.Area(), 1e-9)
+
+		for _, p := range pts {
+			for i := range rect {
+				require.GreaterOrEqual(t, cross(rect[i], rect[(i+1)%4], p), -1e-9)
+			}
+		}
+	}
+}
+
+func TestMinEnclosingCircle(t *testing.T) {
+	tests := []struct {
+		name     string
+		points   []Point
+		expected Circle
+	}{
+		{
+			name:     "single point",
+			points:   []Point{{3, 4}},
+			expected: Circle{Center: Point{3, 4}},
+		},
+		{
+			name:     "two points",
+			points:   []Point{{0, 0}, {4, 0}},
+			expected: Circle{Center: Point{2, 0}, Radius: 2},
+		},
+		{
+			name:     "right triangle uses hypotenuse",
+			points:   []Point{{0, 0}, {4, 0}, {0, 3}},
+			expected: Circle{Center: Point{2, 1.5}, Radius: 2.5},
+		},
+		{
+			name:     "equilateral triangle uses circumcircle",
+			points:   []Point{{0, 0}, {2, 0}, {1, math.Sqrt(3)}},
+			expected: Circle{Center: Point{1, math.Sqrt(3) / 3}, Radius: 2 / math.Sqrt(3)},
+		},
+		{
+			name:     "collinear",
+			points:   []Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}},
+			expected: Circle{Center: Point{1.5, 1.5}, Radius: 1.5 * math.Sqrt2},
+		},
+		{
+			name:     "duplicates",
+			points:   []Point{{0, 0}, {0, 0}, {2, 0}, {2, 0}, {1, 0}},
+			expected: Circle{Center: Point{1, 0}, Radius: 1},
+		},
+		{
+			name:     "square with interior points",
+			points:   []Point{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 1}, {0.5, 1.5}},
+			expected: Circle{Center: Point{1, 1}, Radius: math.Sqrt2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := MinEnclosingCircle(tt.points)
+			require.NoError(t, err)
+			require.InDelta(t, tt.expected.Center.X, c.Center.X, 1e-9)
+			require.InDelta(t, tt.expected.Center.Y, c.Center.Y, 1e-9)
+			require.InDelta(t, tt.expected.Radius, c.Radius, 1e-9)
+		})
+	}
+
+	_, err := MinEnclosingCircle(nil)
+	require.ErrorIs(t, err, ErrNoPoints)
+}
+
+func TestMinEnclosingCircleRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 10))
+
+	for iter := 0; iter < 100; iter++ {
+		pts := make([]Point, 2+r.IntN(30))
+		for i := range pts {
+			pts[i] = Point{r.Float64() * 10, r.Float64() * 10}
+		}
+
+		c, err := MinEnclosingCircle(pts)
+		require.NoError(t, err)
+		for _, p := range pts {
+			require.True(t, c.Contains(p))
+		}
+
+		// The optimum is fixed by two or three of the points; no circle
+		// through such a subset that holds all points is smaller.
+		best := math.Inf(1)
+		for i := range pts {
+			for j := i + 1; j < len(pts); j++ {
+				candidates := []Circle{circleFrom2(pts[i], pts[j])}
+				for k := j + 1; k < len(pts); k++ {
+					candidates = append(candidates, circleFrom3(pts[i], pts[j], pts[k]))
+				}
+				for _, candidate := range candidates {
+					if candidate.Radius < best && containsAll(candidate, pts) {
+						best = candidate.Radius
+					}
+				}
+			}
+		}
+		require.InDelta(t, best, c.Radius, 1e-9)
+
+		again, err := MinEnclosingCircle(pts)
+		require.NoError(t, err)
+		require.Equal(t, c, again)
+	}
+}
+
+func containsAll(c Circle, pts []Point) bool {
+	for _, p := range pts {
+		if !c.Contains(p) {
+			return false
+		}
+	}
+	return true
+}
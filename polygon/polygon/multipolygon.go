@@ -0,0 +1,320 @@
+package polygon
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrHoleOutside  = errors.New("hole is not inside the outer ring")
+	ErrHolesOverlap = errors.New("holes overlap")
+	ErrPartsOverlap = errors.New("polygons overlap")
+)
+
+// WithHoles is a polygon whose outer ring may enclose holes. The outer ring
+// runs counter-clockwise and the holes clockwise; NewWithHoles and Orient
+// establish this.
+type WithHoles struct {
+	Outer Polygon
+	Holes []Polygon
+}
+
+// MultiPolygon is a set of polygons whose interiors do not overlap.
+type MultiPolygon []WithHoles
+
+// NewWithHoles copies the rings, orienting the outer ring counter-clockwise
+// and the holes clockwise.
+func NewWithHoles(outer Polygon, holes ...Polygon) (WithHoles, error) {
+	o, err := New(outer)
+	if err != nil {
+		return WithHoles{}, err
+	}
+
+	result := WithHoles{Outer: o}
+	for i, hole := range holes {
+		h, err := New(hole)
+		if err != nil {
+			return WithHoles{}, fmt.Errorf("hole %d: %w", i, err)
+		}
+		result.Holes = append(result.Holes, h)
+	}
+
+	return result.Orient(), nil
+}
+
+// Orient returns a copy with the outer ring counter-clockwise and the holes
+// clockwise.
+func (p WithHoles) Orient() WithHoles {
+	result := WithHoles{Outer: ccw(p.Outer)}
+	for _, hole := range p.Holes {
+		result.Holes = append(result.Holes, ccw(hole).Reverse())
+	}
+	return result
+}
+
+// IsOriented reports whether the outer ring runs counter-clockwise and
+// every hole clockwise.
+func (p WithHoles) IsOriented() bool {
+	if p.Outer.SignedArea() <= 0 {
+		return false
+	}
+	for _, hole := range p.Holes {
+		if hole.SignedArea() >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks each ring with Polygon.Validate, then that every hole
+// lies inside the outer ring and outside the other holes. Holes may touch
+// the outer ring or each other at single points, but not cross them or
+// share a stretch of edge.
+func (p WithHoles) Validate() error {
+	if err := p.Outer.Validate(); err != nil {
+		return fmt.Errorf("outer ring: %w", err)
+	}
+
+	for i, hole := range p.Holes {
+		if err := hole.Validate(); err != nil {
+			return fmt.Errorf("hole %d: %w", i, err)
+		}
+		if locs := ringLocations(hole, p.Outer); locs[Outside] || locs[OnBoundary] {
+			return fmt.Errorf("hole %d: %w", i, ErrHoleOutside)
+		}
+		for j := 0; j < i; j++ {
+			a, b := ringLocations(hole, p.Holes[j]), ringLocations(p.Holes[j], hole)
+			if a[Inside] || a[OnBoundary] || b[Inside] || b[OnBoundary] {
+				return fmt.Errorf("holes %d and %d: %w", j, i, ErrHolesOverlap)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ringLocations reports where the boundary of ring lies relative to other.
+// Each edge of ring is cut wherever it meets the boundary of other and the
+// midpoint of every piece is located, so the pieces lie wholly on one side
+// and points where the rings merely touch are left out.
+func ringLocations(ring, other Polygon) map[Location]bool {
+	return boundaryLocations(ring, []Polygon{other}, other.Locate)
+}
+
+// partLocations reports where the rings of part lie relative to other, as
+// ringLocations does for a single ring.
+func partLocations(part, other WithHoles) map[Location]bool {
+	boundary := MultiPolygon{other}.rings()
+	locs := make(map[Location]bool)
+	for _, ring := range (MultiPolygon{part}).rings() {
+		for loc := range boundaryLocations(ring, boundary, other.Locate) {
+			locs[loc] = true
+		}
+	}
+	return locs
+}
+
+// boundaryLocations cuts every edge of ring where it meets one of the
+// boundary rings and locates the midpoint of each piece with locate.
+func boundaryLocations(ring Polygon, boundary []Polygon, locate func(Point) Location) map[Location]bool {
+	locs := make(map[Location]bool)
+	for i := range ring {
+		a, b := ring[i], ring[(i+1)%len(ring)]
+
+		cuts := []float64{0, 1}
+		for _, other := range boundary {
+			for j := range other {
+				c, d := other[j], other[(j+1)%len(other)]
+				if !segmentsIntersect(a, b, c, d) {
+					continue
+				}
+				ca, cb := cross(c, d, a), cross(c, d, b)
+				switch {
+				case ca == cb:
+					// Collinear: cut where the overlap starts and ends.
+					cuts = append(cuts, edgeParam(a, b, c), edgeParam(a, b, d))
+				case cross(a, b, c) == 0:
+					// A vertex of other on the edge is cut at exactly, so
+					// the edges on either side of it cut at the same place.
+					cuts = append(cuts, edgeParam(a, b, c))
+				case cross(a, b, d) == 0:
+					cuts = append(cuts, edgeParam(a, b, d))
+				default:
+					cuts = append(cuts, ca/(ca-cb))
+				}
+			}
+		}
+
+		slices.Sort(cuts)
+		for k := 1; k < len(cuts); k++ {
+			t0, t1 := max(cuts[k-1], 0), min(cuts[k], 1)
+			if t0 >= t1 {
+				continue
+			}
+			t := (t0 + t1) / 2
+			locs[locate(Point{a.X + t*(b.X-a.X), a.Y + t*(b.Y-a.Y)})] = true
+		}
+	}
+	return locs
+}
+
+// edgeParam returns the position of p, collinear with a and b, along the
+// line from a to b, with a at 0 and b at 1.
+func edgeParam(a, b, p Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	return ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / (dx*dx + dy*dy)
+}
+
+// Area returns the area of the outer ring less the area of the holes.
+func (p WithHoles) Area() float64 {
+	area := p.Outer.Area()
+	for _, hole := range p.Holes {
+		area -= hole.Area()
+	}
+	return area
+}
+
+// Perimeter returns the length of the outer ring and all holes.
+func (p WithHoles) Perimeter() float64 {
+	per := p.Outer.Perimeter()
+	for _, hole := range p.Holes {
+		per += hole.Perimeter()
+	}
+	return per
+}
+
+// Locate classifies pt: points on the outer ring or on a hole's ring are
+// OnBoundary, and points strictly inside a hole are Outside.
+func (p WithHoles) Locate(pt Point) Location {
+	loc := p.Outer.Locate(pt)
+	if loc != Inside {
+		return loc
+	}
+
+	for _, hole := range p.Holes {
+		switch hole.Locate(pt) {
+		case Inside:
+			return Outside
+		case OnBoundary:
+			return OnBoundary
+		}
+	}
+	return Inside
+}
+
+// Contains reports whether pt lies inside p or on its boundary.
+func (p WithHoles) Contains(pt Point) bool {
+	return p.Locate(pt) != Outside
+}
+
+// Orient returns a copy with every part oriented as by WithHoles.Orient.
+func (mp MultiPolygon) Orient() MultiPolygon {
+	result := make(MultiPolygon, len(mp))
+	for i, p := range mp {
+		result[i] = p.Orient()
+	}
+	return result
+}
+
+// IsOriented reports whether every part is oriented.
+func (mp MultiPolygon) IsOriented() bool {
+	for _, p := range mp {
+		if !p.IsOriented() {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks every part with WithHoles.Validate, then that the
+// interiors of the parts do not overlap. Parts may touch or share a stretch
+// of edge, but not cross, nest inside one another or cover the same area.
+func (mp MultiPolygon) Validate() error {
+	for i, p := range mp {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("polygon %d: %w", i, err)
+		}
+	}
+
+	for i := range mp {
+		for j := 0; j < i; j++ {
+			// A part whose boundary never enters the other and runs along
+			// it everywhere, both ways round, covers the same area.
+			a, b := partLocations(mp[i], mp[j]), partLocations(mp[j], mp[i])
+			if a[Inside] || b[Inside] || !a[Outside] && !b[Outside] {
+				return fmt.Errorf("polygons %d and %d: %w", j, i, ErrPartsOverlap)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Area returns the total area of the parts.
+func (mp MultiPolygon) Area() float64 {
+	area := 0.0
+	for _, p := range mp {
+		area += p.Area()
+	}
+	return area
+}
+
+// Perimeter returns the total length of every ring of every part.
+func (mp MultiPolygon) Perimeter() float64 {
+	per := 0.0
+	for _, p := range mp {
+		per += p.Perimeter()
+	}
+	return per
+}
+
+// Locate classifies pt against the parts. A point on the boundary of one
+// part is OnBoundary even where another part touches it.
+func (mp MultiPolygon) Locate(pt Point) Location {
+	result := Outside
+	for _, p := range mp {
+		switch p.Locate(pt) {
+		case OnBoundary:
+			return OnBoundary
+		case Inside:
+			result = Inside
+		}
+	}
+	return result
+}
+
+// Contains reports whether pt lies inside any part or on its boundary.
+func (mp MultiPolygon) Contains(pt Point) bool {
+	return mp.Locate(pt) != Outside
+}
+
+// rings returns every ring of the multi-polygon, outer rings and holes
+// alike.
+func (mp MultiPolygon) rings() []Polygon {
+	var result []Polygon
+	for _, p := range mp {
+		result = append(result, p.Outer)
+		result = append(result, p.Holes...)
+	}
+	return result
+}
+
+// locateRings classifies p against a set of consistently oriented rings
+// using the nonzero winding rule over all of them, so clockwise holes
+// cancel the counter-clockwise rings around them.
+func locateRings(rings []Polygon, p Point) Location {
+	winding := 0
+	for _, ring := range rings {
+		w, onBoundary := ring.winding(p)
+		if onBoundary {
+			return OnBoundary
+		}
+		winding += w
+	}
+
+	if winding != 0 {
+		return Inside
+	}
+	return Outside
+}
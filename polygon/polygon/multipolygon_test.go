@@ -0,0 +1,291 @@
+package polygon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWithHoles(t *testing.T) {
+	outer := Polygon{{0, 0}, {0, 10}, {10, 10}, {10, 0}}
+	hole := Polygon{{2, 2}, {4, 2}, {4, 4}, {2, 4}}
+
+	p, err := NewWithHoles(outer, hole)
+	require.NoError(t, err)
+	require.True(t, p.IsOriented())
+	require.Positive(t, p.Outer.SignedArea())
+	require.Negative(t, p.Holes[0].SignedArea())
+
+	// The inputs are copied, not reordered in place.
+	require.Equal(t, Polygon{{0, 0}, {0, 10}, {10, 10}, {10, 0}}, outer)
+	require.Equal(t, Polygon{{2, 2}, {4, 2}, {4, 4}, {2, 4}}, hole)
+
+	_, err = NewWithHoles(Polygon{{0, 0}, {1, 1}})
+	require.ErrorIs(t, err, ErrTooFewVertices)
+
+	_, err = NewWithHoles(outer, Polygon{{1, 1}})
+	require.ErrorIs(t, err, ErrTooFewVertices)
+	require.ErrorContains(t, err, "hole 0")
+}
+
+func TestWithHolesOrient(t *testing.T) {
+	p := WithHoles{
+		Outer: Polygon{{0, 0}, {0, 10}, {10, 10}, {10, 0}},
+		Holes: []Polygon{{{2, 2}, {4, 2}, {4, 4}, {2, 4}}, {{6, 6}, {6, 8}, {8, 8}, {8, 6}}},
+	}
+	require.False(t, p.IsOriented())
+
+	oriented := p.Orient()
+	require.True(t, oriented.IsOriented())
+	require.Equal(t, Polygon{{10, 0}, {10, 10}, {0, 10}, {0, 0}}, oriented.Outer)
+	require.Equal(t, Polygon{{2, 4}, {4, 4}, {4, 2}, {2, 2}}, oriented.Holes[0])
+	require.Equal(t, p.Holes[1], oriented.Holes[1])
+	require.Equal(t, p.Area(), oriented.Area())
+
+	mp := MultiPolygon{p, oriented}
+	require.False(t, mp.IsOriented())
+	require.True(t, mp.Orient().IsOriented())
+}
+
+func TestWithHolesValidate(t *testing.T) {
+	outer := Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+
+	tests := []struct {
+		name     string
+		polygon  WithHoles
+		expected error
+	}{
+		{
+			name:    "valid",
+			polygon: WithHoles{Outer: outer, Holes: []Polygon{{{2, 2}, {2, 4}, {4, 4}, {4, 2}}, {{6, 6}, {6, 8}, {8, 8}, {8, 6}}}},
+		},
+		{
+			name:    "hole touching outer ring at a vertex",
+			polygon: WithHoles{Outer: outer, Holes: []Polygon{{{0, 0}, {2, 4}, {4, 2}}}},
+		},
+		{
+			name:     "invalid outer ring",
+			polygon:  WithHoles{Outer: Polygon{{0, 0}, {2, 2}, {2, 0}, {0, 2}}},
+			expected: &SelfIntersectionError{Edge1: 0, Edge2: 2},
+		},
+		{
+			name:     "degenerate hole",
+			polygon:  WithHoles{Outer: outer, Holes: []Polygon{{{1, 1}, {2, 2}, {3, 3}}}},
+			expected: ErrDegenerate,
+		},
+		{
+			name:     "hole outside",
+			polygon:  WithHoles{Outer: outer, Holes: []Polygon{{{12, 2}, {12, 4}, {14, 4}}}},
+			expected: ErrHoleOutside,
+		},
+		{
+			name:     "hole crossing the outer ring",
+			polygon:  WithHoles{Outer: outer, Holes: []Polygon{{{9, 4}, {9, 6}, {11, 6}, {11, 4}}}},
+			expected: ErrHoleOutside,
+		},
+		{
+			name:     "hole sharing an edge with the outer ring",
+			polygon:  WithHoles{Outer: outer, Holes: []Polygon{{{8, 4}, {8, 6}, {10, 6}, {10, 4}}}},
+			expected: ErrHoleOutside,
+		},
+		{
+			name:    "holes touching at a vertex",
+			polygon: WithHoles{Outer: outer, Holes: []Polygon{{{2, 2}, {2, 4}, {4, 4}, {4, 2}}, {{4, 4}, {4, 6}, {6, 6}, {6, 4}}}},
+		},
+		{
+			name:     "overlapping holes",
+			polygon:  WithHoles{Outer: outer, Holes: []Polygon{{{2, 2}, {2, 6}, {6, 6}, {6, 2}}, {{4, 4}, {4, 8}, {8, 8}, {8, 4}}}},
+			expected: ErrHolesOverlap,
+		},
+		{
+			name:     "holes sharing an edge",
+			polygon:  WithHoles{Outer: outer, Holes: []Polygon{{{2, 2}, {2, 4}, {4, 4}, {4, 2}}, {{4, 2}, {4, 4}, {6, 4}, {6, 2}}}},
+			expected: ErrHolesOverlap,
+		},
+		{
+			name:     "nested holes",
+			polygon:  WithHoles{Outer: outer, Holes: []Polygon{{{1, 1}, {1, 9}, {9, 9}, {9, 1}}, {{2, 2}, {2, 4}, {4, 4}, {4, 2}}}},
+			expected: ErrHolesOverlap,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.polygon.Validate()
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, MultiPolygon{tt.polygon}.Validate())
+
+			var intersection *SelfIntersectionError
+			if errors.As(err, &intersection) {
+				require.Equal(t, tt.expected, intersection)
+				return
+			}
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMultiPolygonValidate(t *testing.T) {
+	square := WithHoles{Outer: Polygon{{0, 0}, {4, 0}, {4, 4}, {0, 4}}}
+	framed := WithHoles{
+		Outer: Polygon{{-2, -2}, {6, -2}, {6, 6}, {-2, 6}},
+		Holes: []Polygon{{{0, 0}, {0, 4}, {4, 4}, {4, 0}}},
+	}
+
+	tests := []struct {
+		name     string
+		polygon  MultiPolygon
+		expected error
+	}{
+		{
+			name:    "disjoint",
+			polygon: MultiPolygon{square, {Outer: Polygon{{5, 0}, {6, 0}, {6, 1}}}},
+		},
+		{
+			name:    "sharing an edge",
+			polygon: MultiPolygon{square, {Outer: Polygon{{4, 0}, {8, 0}, {8, 4}, {4, 4}}}},
+		},
+		{
+			name:    "touching at a corner",
+			polygon: MultiPolygon{square, {Outer: Polygon{{4, 4}, {8, 4}, {8, 8}, {4, 8}}}},
+		},
+		{
+			name:    "filling a hole",
+			polygon: MultiPolygon{framed, square},
+		},
+		{
+			name:    "inside a hole",
+			polygon: MultiPolygon{framed, {Outer: Polygon{{1, 1}, {3, 1}, {3, 3}, {1, 3}}}},
+		},
+		{
+			// The first part runs straight past a vertex of the second,
+			// whose edges end at a rounded crossing point.
+			name: "touching mid-edge",
+			polygon: MultiPolygon{
+				{Outer: Polygon{{2, 2}, {0, 3}, {-2, 3}, {-3, 1}, {-1, -2}, {1, 0}, {1, 2}, {2, 1}, {2.6666666666666665, 0.7777777777777777}, {3, 1}}},
+				{Outer: Polygon{{2.6666666666666665, 0.7777777777777777}, {0, -1}, {3, -2}, {3, -1}, {5, 0}}},
+			},
+		},
+		{
+			name:     "crossing",
+			polygon:  MultiPolygon{square, {Outer: Polygon{{2, 2}, {6, 2}, {6, 6}, {2, 6}}}},
+			expected: ErrPartsOverlap,
+		},
+		{
+			name:     "nested",
+			polygon:  MultiPolygon{square, {Outer: Polygon{{1, 1}, {3, 1}, {3, 3}, {1, 3}}}},
+			expected: ErrPartsOverlap,
+		},
+		{
+			name:     "nested sharing an edge",
+			polygon:  MultiPolygon{square, {Outer: Polygon{{0, 0}, {2, 0}, {2, 2}, {0, 2}}}},
+			expected: ErrPartsOverlap,
+		},
+		{
+			name:     "same area",
+			polygon:  MultiPolygon{square, {Outer: Polygon{{4, 4}, {4, 0}, {0, 0}, {0, 4}}}},
+			expected: ErrPartsOverlap,
+		},
+		{
+			name:     "invalid part",
+			polygon:  MultiPolygon{square, {Outer: Polygon{{0, 0}, {1, 1}}}},
+			expected: ErrTooFewVertices,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.polygon.Validate()
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestWithHolesMeasures(t *testing.T) {
+	p, err := NewWithHoles(
+		Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		Polygon{{2, 2}, {4, 2}, {4, 4}, {2, 4}},
+		Polygon{{6, 6}, {9, 6}, {9, 9}, {6, 9}},
+	)
+	require.NoError(t, err)
+
+	require.InDelta(t, 100-4-9, p.Area(), 1e-12)
+	require.InDelta(t, 40+8+12, p.Perimeter(), 1e-12)
+
+	// Orientation does not change the measures.
+	unoriented := WithHoles{Outer: p.Outer.Reverse(), Holes: []Polygon{p.Holes[0].Reverse(), p.Holes[1]}}
+	require.InDelta(t, p.Area(), unoriented.Area(), 1e-12)
+	require.InDelta(t, p.Perimeter(), unoriented.Perimeter(), 1e-12)
+
+	far, err := NewWithHoles(Polygon{{20, 0}, {22, 0}, {22, 2}, {20, 2}})
+	require.NoError(t, err)
+
+	mp := MultiPolygon{p, far}
+	require.InDelta(t, 87+4, mp.Area(), 1e-12)
+	require.InDelta(t, 60+8, mp.Perimeter(), 1e-12)
+	require.Zero(t, MultiPolygon{}.Area())
+}
+
+func TestWithHolesLocate(t *testing.T) {
+	p, err := NewWithHoles(
+		Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		Polygon{{2, 2}, {4, 2}, {4, 4}, {2, 4}},
+	)
+	require.NoError(t, err)
+
+	far, err := NewWithHoles(Polygon{{20, 0}, {22, 0}, {22, 2}, {20, 2}})
+	require.NoError(t, err)
+
+	// Shares the edge x = 10 with p.
+	neighbour, err := NewWithHoles(Polygon{{10, 0}, {12, 0}, {12, 2}, {10, 2}})
+	require.NoError(t, err)
+
+	mp := MultiPolygon{p, far, neighbour}
+
+	tests := []struct {
+		name     string
+		point    Point
+		polygon  Location
+		multi    Location
+		contains bool
+	}{
+		{name: "inside", point: Point{6, 6}, polygon: Inside, multi: Inside, contains: true},
+		{name: "inside hole", point: Point{3, 3}, polygon: Outside, multi: Outside, contains: false},
+		{name: "on hole edge", point: Point{3, 2}, polygon: OnBoundary, multi: OnBoundary, contains: true},
+		{name: "on hole vertex", point: Point{4, 4}, polygon: OnBoundary, multi: OnBoundary, contains: true},
+		{name: "on outer edge", point: Point{0, 5}, polygon: OnBoundary, multi: OnBoundary, contains: true},
+		{name: "outside", point: Point{15, 5}, polygon: Outside, multi: Outside, contains: false},
+		{name: "inside other part", point: Point{21, 1}, polygon: Outside, multi: Inside, contains: true},
+		{name: "on shared edge", point: Point{10, 1}, polygon: OnBoundary, multi: OnBoundary, contains: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.polygon, p.Locate(tt.point))
+			require.Equal(t, tt.polygon != Outside, p.Contains(tt.point))
+			require.Equal(t, tt.multi, mp.Locate(tt.point))
+			require.Equal(t, tt.contains, mp.Contains(tt.point))
+		})
+	}
+}
+
+func TestClipResultsAreOriented(t *testing.T) {
+	cShape := Polygon{{0, 0}, {4, 0}, {4, 1}, {1, 1}, {1, 3}, {4, 3}, {4, 4}, {0, 4}}
+	bar := Polygon{{3, 4}, {5, 4}, {5, 0}, {3, 0}}
+
+	union := cShape.Union(bar)
+	require.True(t, union.IsOriented())
+	require.NoError(t, union.Validate())
+	require.InDelta(t, 16, union.Area(), 1e-12)
+	require.InDelta(t, 18+8, union.Perimeter(), 1e-12)
+	require.Equal(t, Outside, union.Locate(Point{2, 2}))
+}
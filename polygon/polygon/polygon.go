@@ -1,9 +1,6 @@
 package polygon
 
-import (
-	"errors"
-	"math"
-)
+import "math"
 
 type Point struct {
 	X float64
@@ -14,7 +11,7 @@ type Polygon []Point
 
 func New(pts []Point) (Polygon, error) {
 	if len(pts) < 3 {
-		return nil, errors.New("polygon needs at least 3 points")
+		return nil, ErrTooFewVertices
 	}
 
 	cp := make([]Point, len(pts))
@@ -23,22 +20,7 @@ func New(pts []Point) (Polygon, error) {
 }
 
 func (pg Polygon) Area() float64 {
-	n := len(pg)
-
-	if n < 3 {
-		return 0
-	}
-
-	sum := 0.0
-
-	for i := 0; i < n; i++ {
-		j := (i + 1) % n
-		x1, y1 := pg[i].X, pg[i].Y
-		x2, y2 := pg[j].X, pg[j].Y
-		sum += x1*y2 - x2*y1
-	}
-
-	return math.Abs(sum) / 2
+	return math.Abs(pg.SignedArea())
 }
 
 func (pg Polygon) Perimeter() float64 {
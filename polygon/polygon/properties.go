@@ -0,0 +1,182 @@
+package polygon
+
+import "math"
+
+// Orientation is the direction in which a ring's vertices run.
+type Orientation int
+
+const (
+	Degenerate Orientation = iota
+	CounterClockwise
+	Clockwise
+)
+
+func (o Orientation) String() string {
+	switch o {
+	case CounterClockwise:
+		return "counter-clockwise"
+	case Clockwise:
+		return "clockwise"
+	default:
+		return "degenerate"
+	}
+}
+
+// Moments are the second moments of area of a region.
+type Moments struct {
+	Ixx float64 // ∫y² dA, about the x axis
+	Iyy float64 // ∫x² dA, about the y axis
+	Ixy float64 // ∫xy dA, the product of area
+}
+
+// Polar returns the polar moment of area about the origin of the axes.
+func (m Moments) Polar() float64 {
+	return m.Ixx + m.Iyy
+}
+
+// SignedArea returns the area enclosed by pg, positive when the vertices
+// run counter-clockwise and negative when they run clockwise. Regions that
+// a self-intersecting ring winds around in opposite directions cancel out.
+func (pg Polygon) SignedArea() float64 {
+	n := len(pg)
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		j := (i + 1) % n
+		sum += pg[i].X*pg[j].Y - pg[j].X*pg[i].Y
+	}
+	return sum / 2
+}
+
+// Orientation reports the direction of pg from the sign of its area.
+func (pg Polygon) Orientation() Orientation {
+	switch area := pg.SignedArea(); {
+	case area > 0:
+		return CounterClockwise
+	case area < 0:
+		return Clockwise
+	default:
+		return Degenerate
+	}
+}
+
+// Reverse returns a copy of pg with the vertices in the opposite order.
+func (pg Polygon) Reverse() Polygon {
+	result := make(Polygon, len(pg))
+	for i, p := range pg {
+		result[len(pg)-1-i] = p
+	}
+	return result
+}
+
+// IsConvex reports whether pg is a convex polygon in either orientation:
+// every vertex turns the same way or runs straight on, and the boundary
+// goes round exactly once, which rules out stars such as the pentagram.
+// Repeated vertices are ignored; rings with zero area are not convex.
+func (pg Polygon) IsConvex() bool {
+	ring := make(Polygon, 0, len(pg))
+	for i, p := range pg {
+		if p != pg[(i+1)%len(pg)] {
+			ring = append(ring, p)
+		}
+	}
+	n := len(ring)
+	if n < 3 {
+		return false
+	}
+
+	sign := 0.0
+	turning := 0.0
+	for i := 0; i < n; i++ {
+		a, b, c := ring[i], ring[(i+1)%n], ring[(i+2)%n]
+		if s := cross(a, b, c); s != 0 {
+			if sign != 0 && (s > 0) != (sign > 0) {
+				return false
+			}
+			sign = s
+		}
+		turning += turnAngle(a, b, c)
+	}
+
+	// The turns of a simple ring add up to one full turn; a ring going
+	// round twice, or folding back on itself, adds up to more.
+	return sign != 0 && math.Abs(turning) < 3*math.Pi
+}
+
+// Centroid returns the centre of mass of the area enclosed by pg. It
+// returns ErrTooFewVertices for fewer than three vertices and ErrDegenerate
+// when the area is zero.
+func (pg Polygon) Centroid() (Point, error) {
+	if len(pg) < 3 {
+		return Point{}, ErrTooFewVertices
+	}
+
+	area, c, _ := integrate(pg, pg[0])
+	if area == 0 {
+		return Point{}, ErrDegenerate
+	}
+	return Point{pg[0].X + c.X, pg[0].Y + c.Y}, nil
+}
+
+// SecondMoments returns the second moments of the area enclosed by pg about
+// the x and y axes. They do not depend on the orientation of pg.
+func (pg Polygon) SecondMoments() Moments {
+	area, _, m := integrate(pg, Point{})
+	if area < 0 {
+		return Moments{Ixx: -m.Ixx, Iyy: -m.Iyy, Ixy: -m.Ixy}
+	}
+	return m
+}
+
+// CentroidalMoments returns the second moments of area about axes through
+// the centroid, parallel to the x and y axes, as used for bending and
+// rotation. It returns the same errors as Centroid.
+func (pg Polygon) CentroidalMoments() (Moments, error) {
+	if len(pg) < 3 {
+		return Moments{}, ErrTooFewVertices
+	}
+
+	area, c, m := integrate(pg, pg[0])
+	if area == 0 {
+		return Moments{}, ErrDegenerate
+	}
+
+	// Parallel axis theorem, from axes through pg[0] to the centroid.
+	m = Moments{
+		Ixx: m.Ixx - area*c.Y*c.Y,
+		Iyy: m.Iyy - area*c.X*c.X,
+		Ixy: m.Ixy - area*c.X*c.Y,
+	}
+	if area < 0 {
+		m = Moments{Ixx: -m.Ixx, Iyy: -m.Iyy, Ixy: -m.Ixy}
+	}
+	return m, nil
+}
+
+// integrate returns the signed area of pg, its centroid and its signed
+// second moments, all with coordinates taken relative to origin. Choosing
+// an origin near the polygon avoids cancellation far from (0, 0).
+func integrate(pg Polygon, origin Point) (float64, Point, Moments) {
+	var area, cx, cy float64
+	var m Moments
+
+	n := len(pg)
+	for i := 0; i < n; i++ {
+		x1, y1 := pg[i].X-origin.X, pg[i].Y-origin.Y
+		x2, y2 := pg[(i+1)%n].X-origin.X, pg[(i+1)%n].Y-origin.Y
+		a := x1*y2 - x2*y1
+
+		area += a
+		cx += (x1 + x2) * a
+		cy += (y1 + y2) * a
+		m.Ixx += (y1*y1 + y1*y2 + y2*y2) * a
+		m.Iyy += (x1*x1 + x1*x2 + x2*x2) * a
+		m.Ixy += (x1*y2 + 2*x1*y1 + 2*x2*y2 + x2*y1) * a
+	}
+
+	area /= 2
+	m = Moments{Ixx: m.Ixx / 12, Iyy: m.Iyy / 12, Ixy: m.Ixy / 24}
+	if area == 0 {
+		return 0, Point{}, m
+	}
+	return area, Point{cx / (6 * area), cy / (6 * area)}, m
+}
@@ -0,0 +1,220 @@
+package polygon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	unitSquare = Polygon{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	lShape     = Polygon{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}}
+	pentagram  = Polygon{{0, 3}, {2, -3}, {-3, 1}, {3, 1}, {-2, -3}}
+)
+
+func TestSignedAreaAndOrientation(t *testing.T) {
+	tests := []struct {
+		name        string
+		polygon     Polygon
+		area        float64
+		orientation Orientation
+	}{
+		{
+			name:        "counter-clockwise square",
+			polygon:     unitSquare,
+			area:        1,
+			orientation: CounterClockwise,
+		},
+		{
+			name:        "clockwise square",
+			polygon:     unitSquare.Reverse(),
+			area:        -1,
+			orientation: Clockwise,
+		},
+		{
+			name:        "clockwise L shape",
+			polygon:     lShape.Reverse(),
+			area:        -3,
+			orientation: Clockwise,
+		},
+		{
+			name:        "collinear",
+			polygon:     Polygon{{0, 0}, {1, 1}, {2, 2}},
+			area:        0,
+			orientation: Degenerate,
+		},
+		{
+			name:        "bowtie cancels out",
+			polygon:     Polygon{{0, 0}, {2, 2}, {2, 0}, {0, 2}},
+			area:        0,
+			orientation: Degenerate,
+		},
+		{
+			name:        "too few vertices",
+			polygon:     Polygon{{0, 0}, {1, 1}},
+			area:        0,
+			orientation: Degenerate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.area, tt.polygon.SignedArea(), 1e-12)
+			require.InDelta(t, math.Abs(tt.area), tt.polygon.Area(), 1e-12)
+			require.Equal(t, tt.orientation, tt.polygon.Orientation())
+		})
+	}
+
+	require.Equal(t, "counter-clockwise", CounterClockwise.String())
+	require.Equal(t, "clockwise", Clockwise.String())
+	require.Equal(t, "degenerate", Degenerate.String())
+}
+
+func TestReverse(t *testing.T) {
+	original := Polygon{{0, 0}, {2, 0}, {1, 3}}
+	reversed := original.Reverse()
+
+	require.Equal(t, Polygon{{1, 3}, {2, 0}, {0, 0}}, reversed)
+	require.Equal(t, Polygon{{0, 0}, {2, 0}, {1, 3}}, original)
+	require.Equal(t, original, reversed.Reverse())
+	require.Empty(t, Polygon{}.Reverse())
+}
+
+func TestIsConvex(t *testing.T) {
+	tests := []struct {
+		name     string
+		polygon  Polygon
+		expected bool
+	}{
+		{name: "square", polygon: unitSquare, expected: true},
+		{name: "clockwise square", polygon: unitSquare.Reverse(), expected: true},
+		{name: "triangle", polygon: Polygon{{0, 0}, {4, 0}, {1, 3}}, expected: true},
+		{name: "vertex on an edge", polygon: Polygon{{0, 0}, {1, 0}, {2, 0}, {2, 2}, {0, 2}}, expected: true},
+		{name: "repeated vertices", polygon: Polygon{{0, 0}, {2, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}, expected: true},
+		{name: "L shape", polygon: lShape, expected: false},
+		{name: "pentagram", polygon: pentagram, expected: false},
+		{name: "bowtie", polygon: Polygon{{0, 0}, {2, 2}, {2, 0}, {0, 2}}, expected: false},
+		{name: "spike folding back", polygon: Polygon{{0, 0}, {2, 0}, {4, 0}, {2, 0}, {2, 2}}, expected: false},
+		{name: "collinear", polygon: Polygon{{0, 0}, {1, 1}, {2, 2}}, expected: false},
+		{name: "too few vertices", polygon: Polygon{{0, 0}, {1, 1}}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.polygon.IsConvex())
+		})
+	}
+}
+
+func TestCentroid(t *testing.T) {
+	tests := []struct {
+		name     string
+		polygon  Polygon
+		expected Point
+	}{
+		{
+			name:     "rectangle",
+			polygon:  Polygon{{1, 2}, {5, 2}, {5, 4}, {1, 4}},
+			expected: Point{3, 3},
+		},
+		{
+			name:     "triangle",
+			polygon:  Polygon{{0, 0}, {3, 0}, {0, 3}},
+			expected: Point{1, 1},
+		},
+		{
+			name:     "L shape",
+			polygon:  lShape,
+			expected: Point{5.0 / 6, 5.0 / 6},
+		},
+		{
+			name:     "clockwise L shape",
+			polygon:  lShape.Reverse(),
+			expected: Point{5.0 / 6, 5.0 / 6},
+		},
+		{
+			name:     "far from the origin",
+			polygon:  Polygon{{1e8, 1e8}, {1e8 + 3, 1e8}, {1e8, 1e8 + 3}},
+			expected: Point{1e8 + 1, 1e8 + 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := tt.polygon.Centroid()
+			require.NoError(t, err)
+			require.InDelta(t, tt.expected.X, c.X, 1e-9)
+			require.InDelta(t, tt.expected.Y, c.Y, 1e-9)
+		})
+	}
+
+	_, err := Polygon{{0, 0}, {1, 1}}.Centroid()
+	require.ErrorIs(t, err, ErrTooFewVertices)
+
+	_, err = Polygon{{0, 0}, {1, 1}, {2, 2}}.Centroid()
+	require.ErrorIs(t, err, ErrDegenerate)
+}
+
+func TestSecondMoments(t *testing.T) {
+	const w, h = 4.0, 2.0
+
+	tests := []struct {
+		name       string
+		polygon    Polygon
+		origin     Moments
+		centroidal Moments
+	}{
+		{
+			name:       "rectangle at the origin",
+			polygon:    Polygon{{0, 0}, {w, 0}, {w, h}, {0, h}},
+			origin:     Moments{Ixx: w * h * h * h / 3, Iyy: h * w * w * w / 3, Ixy: w * w * h * h / 4},
+			centroidal: Moments{Ixx: w * h * h * h / 12, Iyy: h * w * w * w / 12},
+		},
+		{
+			name:       "rectangle centred on the origin",
+			polygon:    Polygon{{-w / 2, -h / 2}, {w / 2, -h / 2}, {w / 2, h / 2}, {-w / 2, h / 2}},
+			origin:     Moments{Ixx: w * h * h * h / 12, Iyy: h * w * w * w / 12},
+			centroidal: Moments{Ixx: w * h * h * h / 12, Iyy: h * w * w * w / 12},
+		},
+		{
+			name:       "right triangle",
+			polygon:    Polygon{{0, 0}, {w, 0}, {0, h}},
+			origin:     Moments{Ixx: w * h * h * h / 12, Iyy: h * w * w * w / 12, Ixy: w * w * h * h / 24},
+			centroidal: Moments{Ixx: w * h * h * h / 36, Iyy: h * w * w * w / 36, Ixy: -w * w * h * h / 72},
+		},
+		{
+			name:       "clockwise right triangle",
+			polygon:    Polygon{{0, h}, {w, 0}, {0, 0}},
+			origin:     Moments{Ixx: w * h * h * h / 12, Iyy: h * w * w * w / 12, Ixy: w * w * h * h / 24},
+			centroidal: Moments{Ixx: w * h * h * h / 36, Iyy: h * w * w * w / 36, Ixy: -w * w * h * h / 72},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.polygon.SecondMoments()
+			require.InDelta(t, tt.origin.Ixx, m.Ixx, 1e-9)
+			require.InDelta(t, tt.origin.Iyy, m.Iyy, 1e-9)
+			require.InDelta(t, tt.origin.Ixy, m.Ixy, 1e-9)
+			require.InDelta(t, tt.origin.Ixx+tt.origin.Iyy, m.Polar(), 1e-9)
+
+			c, err := tt.polygon.CentroidalMoments()
+			require.NoError(t, err)
+			require.InDelta(t, tt.centroidal.Ixx, c.Ixx, 1e-9)
+			require.InDelta(t, tt.centroidal.Iyy, c.Iyy, 1e-9)
+			require.InDelta(t, tt.centroidal.Ixy, c.Ixy, 1e-9)
+		})
+	}
+
+	// Centroidal moments do not depend on where the polygon is.
+	shifted := Polygon{{1e6, -1e6}, {1e6 + w, -1e6}, {1e6 + w, -1e6 + h}, {1e6, -1e6 + h}}
+	c, err := shifted.CentroidalMoments()
+	require.NoError(t, err)
+	require.InDelta(t, w*h*h*h/12, c.Ixx, 1e-6)
+	require.InDelta(t, h*w*w*w/12, c.Iyy, 1e-6)
+	require.InDelta(t, 0, c.Ixy, 1e-6)
+
+	_, err = Polygon{{0, 0}, {1, 1}, {2, 2}}.CentroidalMoments()
+	require.ErrorIs(t, err, ErrDegenerate)
+}
@@ -0,0 +1,335 @@
+package polygon
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// SimplifyAlgorithm selects how Simplify chooses the vertices to drop.
+type SimplifyAlgorithm int
+
+const (
+	// DouglasPeucker keeps the vertices further than the tolerance from
+	// the simplified outline. The tolerance is a distance.
+	DouglasPeucker SimplifyAlgorithm = iota
+	// VisvalingamWhyatt repeatedly drops the vertex forming the smallest
+	// triangle with its neighbours. The tolerance is an area.
+	VisvalingamWhyatt
+)
+
+func (a SimplifyAlgorithm) String() string {
+	switch a {
+	case DouglasPeucker:
+		return "Douglas-Peucker"
+	case VisvalingamWhyatt:
+		return "Visvalingam-Whyatt"
+	default:
+		return "unknown"
+	}
+}
+
+// Reduction reports how many vertices Simplify kept.
+type Reduction struct {
+	Before int
+	After  int
+}
+
+// Removed returns the number of vertices dropped.
+func (r Reduction) Removed() int {
+	return r.Before - r.After
+}
+
+// Ratio returns the fraction of vertices dropped, between 0 and 1.
+func (r Reduction) Ratio() float64 {
+	if r.Before == 0 {
+		return 0
+	}
+	return float64(r.Removed()) / float64(r.Before)
+}
+
+func (r Reduction) String() string {
+	return fmt.Sprintf("%d of %d vertices removed (%.1f%%)", r.Removed(), r.Before, 100*r.Ratio())
+}
+
+// Simplify returns pg with vertices dropped using algorithm, together with
+// the number of vertices removed. The result keeps a subset of the
+// vertices in their original order and is always a valid polygon: where
+// dropping a vertex would make the outline cross or touch itself, or
+// collapse to a line, the vertex is kept even if it is within the
+// tolerance. pg must pass Validate, whose error is returned otherwise.
+func (pg Polygon) Simplify(tolerance float64, algorithm SimplifyAlgorithm) (Polygon, Reduction, error) {
+	if !(tolerance >= 0) {
+		return nil, Reduction{}, fmt.Errorf("tolerance must not be negative, got %g", tolerance)
+	}
+	if err := pg.Validate(); err != nil {
+		return nil, Reduction{}, err
+	}
+
+	var result Polygon
+	switch algorithm {
+	case DouglasPeucker:
+		result = douglasPeucker(pg, tolerance)
+	case VisvalingamWhyatt:
+		result = visvalingamWhyatt(pg, tolerance)
+	default:
+		return nil, Reduction{}, fmt.Errorf("unknown simplification algorithm %d", algorithm)
+	}
+
+	return result, Reduction{Before: len(pg), After: len(result)}, nil
+}
+
+// douglasPeucker simplifies the ring between the first vertex and the one
+// furthest from it, then splits edges of the result at their furthest
+// dropped vertex until it passes Validate. Each pass splits every edge
+// found crossing another, so few passes are needed even for long rings.
+func douglasPeucker(pg Polygon, tolerance float64) Polygon {
+	n := len(pg)
+	keep := make([]bool, n)
+
+	// farthest returns the vertex strictly between i and j, counting on
+	// past n - 1 to wrap round, that is furthest from the edge joining
+	// them, or -1 if there is none.
+	farthest := func(i, j int) (int, float64) {
+		best, bestDist := -1, -1.0
+		for k := i + 1; k < j; k++ {
+			if d := segmentDistance(pg[k%n], pg[i%n], pg[j%n]); d > bestDist {
+				best, bestDist = k, d
+			}
+		}
+		return best, bestDist
+	}
+
+	var refine func(i, j int)
+	refine = func(i, j int) {
+		if k, d := farthest(i, j); k >= 0 && d > tolerance {
+			keep[k%n] = true
+			refine(i, k)
+			refine(k, j)
+		}
+	}
+
+	far := 0
+	for i := range pg {
+		if dist(pg[0], pg[i]) > dist(pg[0], pg[far]) {
+			far = i
+		}
+	}
+	keep[0], keep[far] = true, true
+	refine(0, far)
+	refine(far, n)
+
+	for {
+		var ring Polygon
+		var index []int
+		for i, p := range pg {
+			if keep[i] {
+				ring = append(ring, p)
+				index = append(index, i)
+			}
+		}
+
+		// pg itself is valid, so while the ring fails Validate some of its
+		// edges span dropped vertices and each pass restores at least one.
+		// Should that ever not hold, the passes below fall back to pg
+		// rather than loop or index with -1.
+		err := ring.Validate()
+		if err == nil {
+			return ring
+		}
+
+		// The edge of the ring starting at vertex m spans the original
+		// vertices index[m] to end(m).
+		end := func(m int) int {
+			if m == len(index)-1 {
+				return index[0] + n
+			}
+			return index[m+1]
+		}
+
+		var crossing *SelfIntersectionError
+		if errors.As(err, &crossing) {
+			// The original edges do not cross, so at least one of each
+			// pair spans some dropped vertices.
+			restored := false
+			ring.intersectingEdges(func(i, j int) bool {
+				for _, m := range []int{i, j} {
+					if k, _ := farthest(index[m], end(m)); k >= 0 {
+						keep[k%n] = true
+						restored = true
+					}
+				}
+				return true
+			})
+			if !restored {
+				return pg
+			}
+			continue
+		}
+
+		// Too few vertices or no area: restore the furthest dropped vertex
+		// from any edge.
+		best, bestDist := -1, -1.0
+		for m := range index {
+			if k, d := farthest(index[m], end(m)); k >= 0 && d > bestDist {
+				best, bestDist = k, d
+			}
+		}
+		if best < 0 {
+			return pg
+		}
+		keep[best%n] = true
+	}
+}
+
+// visvalingamWhyatt drops the vertex with the smallest triangle until none
+// is within the tolerance, passing over vertices whose removal would leave
+// an invalid ring.
+func visvalingamWhyatt(pg Polygon, tolerance float64) Polygon {
+	n := len(pg)
+	prev := make([]int, n)
+	next := make([]int, n)
+	for i := range pg {
+		prev[i] = (i + n - 1) % n
+		next[i] = (i + 1) % n
+	}
+
+	removed := make([]bool, n)
+	count := n
+
+	// stamp invalidates the heap entries of a vertex whenever it is pushed
+	// again, and blockedBy lists the vertices passed over because of the
+	// edge starting at each vertex.
+	stamp := make([]int, n)
+	blockedBy := make([][]int, n)
+	h := &vertexHeap{}
+
+	grid := newEdgeGrid(pg)
+	for i := range pg {
+		grid.insert(i, next[i])
+	}
+
+	area := func(i int) float64 {
+		return math.Abs(cross(pg[prev[i]], pg[i], pg[next[i]])) / 2
+	}
+
+	push := func(i int) {
+		stamp[i]++
+		if a := area(i); !removed[i] && a <= tolerance {
+			heap.Push(h, vertexArea{vertex: i, area: a, stamp: stamp[i]})
+		}
+	}
+
+	// canRemove reports whether the edge replacing vertex i's two edges
+	// keeps the ring simple and with some area. If not, it returns the
+	// vertex starting the edge in the way, or -1 if no other edge changing
+	// would help.
+	canRemove := func(i int) (bool, int) {
+		a, c := prev[i], next[i]
+		if count == 4 && cross(pg[a], pg[c], pg[next[c]]) == 0 {
+			return false, -1
+		}
+
+		// Edges other than the four around i may not touch the new one.
+		blocker := -1
+		grid.visit(pg[a], pg[c], func(e [2]int) bool {
+			j := e[0]
+			if removed[j] || next[j] != e[1] || j == prev[a] || j == a || j == i || j == c {
+				return true
+			}
+			if segmentsIntersect(pg[a], pg[c], pg[j], pg[next[j]]) {
+				blocker = j
+				return false
+			}
+			return true
+		})
+		if blocker >= 0 {
+			return false, blocker
+		}
+
+		// The neighbouring edges share an end with the new one and meet it
+		// elsewhere only by running back along it.
+		before, after := pg[prev[a]], pg[next[c]]
+		if cross(before, pg[a], pg[c]) == 0 && folds(before, pg[a], pg[c]) {
+			return false, prev[a]
+		}
+		if cross(pg[a], pg[c], after) == 0 && folds(pg[a], pg[c], after) {
+			return false, c
+		}
+		return true, -1
+	}
+
+	for i := range pg {
+		push(i)
+	}
+
+	for count > 3 && h.Len() > 0 {
+		best := heap.Pop(h).(vertexArea)
+		i := best.vertex
+		if removed[i] || best.stamp != stamp[i] {
+			continue
+		}
+
+		if ok, blocker := canRemove(i); !ok {
+			if blocker >= 0 {
+				blockedBy[blocker] = append(blockedBy[blocker], i)
+			}
+			continue
+		}
+
+		a, c := prev[i], next[i]
+		removed[i] = true
+		next[a] = c
+		prev[c] = a
+		count--
+		grid.insert(a, c)
+
+		// The edges starting at a and i are gone, which can clear the way
+		// for vertices passed over before. Otherwise only the vertices
+		// whose triangle or neighbouring edges changed need another look.
+		unblocked := append(blockedBy[a], blockedBy[i]...)
+		blockedBy[a], blockedBy[i] = nil, nil
+		for _, j := range append(unblocked, prev[a], a, c, next[c]) {
+			push(j)
+		}
+	}
+
+	result := make(Polygon, 0, count)
+	for i, p := range pg {
+		if !removed[i] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+type vertexArea struct {
+	vertex int
+	area   float64
+	stamp  int
+}
+
+// vertexHeap is a min-heap on triangle area, ties going to the lower
+// vertex index.
+type vertexHeap []vertexArea
+
+func (h vertexHeap) Len() int { return len(h) }
+func (h vertexHeap) Less(i, j int) bool {
+	if h[i].area != h[j].area {
+		return h[i].area < h[j].area
+	}
+	return h[i].vertex < h[j].vertex
+}
+func (h vertexHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *vertexHeap) Push(x any) {
+	*h = append(*h, x.(vertexArea))
+}
+
+func (h *vertexHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,251 @@
+package polygon
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// noisySquare returns the outline of a 10x10 square with n vertices on
+// each side, displaced across the side by up to jitter times the distance
+// to the nearer corner, which keeps the outline simple for jitter below 1.
+func noisySquare(r *rand.Rand, n int, jitter float64) Polygon {
+	corners := []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	var pg Polygon
+	for c, a := range corners {
+		b := corners[(c+1)%4]
+		pg = append(pg, a)
+		for i := 1; i < n; i++ {
+			t := float64(i) / float64(n)
+			offset := (r.Float64()*2 - 1) * jitter * 10 * min(t, 1-t)
+			// Sides run along one axis, so the offset goes on the other.
+			if a.X == b.X {
+				pg = append(pg, Point{a.X + offset, a.Y + t*(b.Y-a.Y)})
+			} else {
+				pg = append(pg, Point{a.X + t*(b.X-a.X), a.Y + offset})
+			}
+		}
+	}
+	return pg
+}
+
+// channel is a U shape whose upper arm bulges up over a spike rising from
+// the lower arm. Cutting off the bulge at {10, 7} would run the upper arm
+// straight through the spike's tip at {10, 5.5}.
+var channel = Polygon{
+	{0, 0}, {20, 0}, {20, 2}, {13, 2}, {10, 5.5}, {7, 2}, {2, 2},
+	{2, 5}, {8, 5}, {10, 7}, {12, 5}, {20, 5}, {20, 8}, {0, 8},
+}
+
+func TestSimplify(t *testing.T) {
+	r := rand.New(rand.NewPCG(11, 12))
+	square := noisySquare(r, 50, 0.01)
+
+	tests := []struct {
+		name      string
+		polygon   Polygon
+		tolerance float64
+		algorithm SimplifyAlgorithm
+		expected  Polygon
+	}{
+		{
+			name:      "noisy square by distance",
+			polygon:   square,
+			tolerance: 0.1,
+			algorithm: DouglasPeucker,
+			expected:  Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		},
+		{
+			name:      "noisy square by area",
+			polygon:   square,
+			tolerance: 0.5,
+			algorithm: VisvalingamWhyatt,
+			expected:  Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		},
+		{
+			name:      "zero tolerance drops collinear vertices by distance",
+			polygon:   Polygon{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 2}, {1, 1.5}, {0, 2}},
+			tolerance: 0,
+			algorithm: DouglasPeucker,
+			expected:  Polygon{{0, 0}, {2, 0}, {2, 2}, {1, 1.5}, {0, 2}},
+		},
+		{
+			name:      "zero tolerance drops collinear vertices by area",
+			polygon:   Polygon{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 2}, {1, 1.5}, {0, 2}},
+			tolerance: 0,
+			algorithm: VisvalingamWhyatt,
+			expected:  Polygon{{0, 0}, {2, 0}, {2, 2}, {1, 1.5}, {0, 2}},
+		},
+		{
+			name:      "huge distance keeps a triangle",
+			polygon:   Polygon{{0, 0}, {4, 0}, {5, 1}, {4, 2}, {0, 2}},
+			tolerance: 100,
+			algorithm: DouglasPeucker,
+			expected:  Polygon{{0, 0}, {5, 1}, {0, 2}},
+		},
+		{
+			name:      "huge area keeps a triangle",
+			polygon:   Polygon{{0, 0}, {4, 0}, {5, 1}, {4, 2}, {0, 2}},
+			tolerance: 100,
+			algorithm: VisvalingamWhyatt,
+			expected:  Polygon{{4, 0}, {4, 2}, {0, 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simplified, reduction, err := tt.polygon.Simplify(tt.tolerance, tt.algorithm)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, simplified)
+			require.Equal(t, Reduction{Before: len(tt.polygon), After: len(tt.expected)}, reduction)
+		})
+	}
+}
+
+func TestSimplifyPreservesTopology(t *testing.T) {
+	tests := []struct {
+		name      string
+		tolerance float64
+		algorithm SimplifyAlgorithm
+	}{
+		{name: "Douglas-Peucker", tolerance: 2.5, algorithm: DouglasPeucker},
+		{name: "Visvalingam-Whyatt", tolerance: 6.5, algorithm: VisvalingamWhyatt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simplified, reduction, err := channel.Simplify(tt.tolerance, tt.algorithm)
+			require.NoError(t, err)
+			require.NoError(t, simplified.Validate())
+			require.Contains(t, simplified, Point{10, 7})
+			require.Contains(t, simplified, Point{10, 5.5})
+			require.Positive(t, reduction.Removed())
+			require.Equal(t, Outside, simplified.Locate(Point{10, 6}))
+		})
+	}
+}
+
+// randomComb returns a 4 units tall strip with n teeth of random height
+// rising from the bottom and n hanging from the top, interleaved so that
+// shortcuts across one side's teeth run into the other side's.
+func randomComb(r *rand.Rand, n int) Polygon {
+	var pg Polygon
+	for i := 0; i < n; i++ {
+		x := float64(2 * i)
+		pg = append(pg, Point{x, 0}, Point{x + 0.5, 0.5 + 3*r.Float64()}, Point{x + 1, 0})
+	}
+	pg = append(pg, Point{float64(2 * n), 0})
+	for i := n - 1; i >= 0; i-- {
+		x := float64(2*i + 2)
+		pg = append(pg, Point{x, 4}, Point{x - 0.5, 3.5 - 3*r.Float64()}, Point{x - 1, 4})
+	}
+	return append(pg, Point{0, 4})
+}
+
+func TestSimplifyRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(13, 14))
+
+	for iter := 0; iter < 40; iter++ {
+		for _, pg := range []Polygon{randomStar(r, Point{}, 50+r.IntN(300)), randomComb(r, 10+r.IntN(100))} {
+			require.NoError(t, pg.Validate())
+
+			for _, algorithm := range []SimplifyAlgorithm{DouglasPeucker, VisvalingamWhyatt} {
+				tolerance := r.Float64() * 3
+				simplified, reduction, err := pg.Simplify(tolerance, algorithm)
+				require.NoError(t, err)
+				require.NoError(t, simplified.Validate(), "%s with tolerance %g", algorithm, tolerance)
+				require.Equal(t, len(pg), reduction.Before)
+				require.Equal(t, len(simplified), reduction.After)
+
+				// The result keeps a subsequence of the original vertices.
+				i := 0
+				for _, p := range simplified {
+					for i < len(pg) && pg[i] != p {
+						i++
+					}
+					require.Less(t, i, len(pg), "%v is not an original vertex in order", p)
+				}
+			}
+		}
+	}
+}
+
+func TestSimplifyLargeRing(t *testing.T) {
+	r := rand.New(rand.NewPCG(17, 18))
+	square := noisySquare(r, 10000, 0.1)
+	comb := randomComb(r, 5000)
+
+	// Checking the input and every repair pass would take tens of seconds
+	// if each compared all pairs of the 40k edges.
+	for _, pg := range []Polygon{square, comb} {
+		for _, algorithm := range []SimplifyAlgorithm{DouglasPeucker, VisvalingamWhyatt} {
+			start := time.Now()
+			simplified, reduction, err := pg.Simplify(0.5, algorithm)
+			require.NoError(t, err)
+			require.NoError(t, simplified.Validate())
+			require.Positive(t, reduction.Removed())
+			require.Less(t, time.Since(start), 5*time.Second, "%s of %d vertices", algorithm, len(pg))
+		}
+	}
+}
+
+func BenchmarkSimplify(b *testing.B) {
+	r := rand.New(rand.NewPCG(19, 20))
+	square := noisySquare(r, 10000, 0.1)
+
+	for _, algorithm := range []SimplifyAlgorithm{DouglasPeucker, VisvalingamWhyatt} {
+		b.Run(algorithm.String(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				square.Simplify(0.5, algorithm)
+			}
+		})
+	}
+}
+
+func TestSimplifyDistanceBound(t *testing.T) {
+	r := rand.New(rand.NewPCG(15, 16))
+	square := noisySquare(r, 200, 0.1)
+
+	for _, tolerance := range []float64{0.1, 0.3, 1} {
+		simplified, _, err := square.Simplify(tolerance, DouglasPeucker)
+		require.NoError(t, err)
+
+		// Every vertex dropped lies within the tolerance of the outline.
+		for _, p := range square {
+			nearest := math.Inf(1)
+			for i := range simplified {
+				nearest = math.Min(nearest, segmentDistance(p, simplified[i], simplified[(i+1)%len(simplified)]))
+			}
+			require.LessOrEqual(t, nearest, tolerance)
+		}
+	}
+}
+
+func TestSimplifyErrors(t *testing.T) {
+	_, _, err := unitSquare.Simplify(-1, DouglasPeucker)
+	require.Error(t, err)
+
+	_, _, err = unitSquare.Simplify(math.NaN(), DouglasPeucker)
+	require.Error(t, err)
+
+	_, _, err = unitSquare.Simplify(1, SimplifyAlgorithm(7))
+	require.Error(t, err)
+
+	_, _, err = Polygon{{0, 0}, {2, 2}, {2, 0}, {0, 2}}.Simplify(1, VisvalingamWhyatt)
+	var crossing *SelfIntersectionError
+	require.ErrorAs(t, err, &crossing)
+}
+
+func TestReduction(t *testing.T) {
+	r := Reduction{Before: 1000, After: 120}
+	require.Equal(t, 880, r.Removed())
+	require.InDelta(t, 0.88, r.Ratio(), 1e-12)
+	require.Equal(t, "880 of 1000 vertices removed (88.0%)", r.String())
+	require.Zero(t, Reduction{}.Ratio())
+
+	require.Equal(t, "Douglas-Peucker", DouglasPeucker.String())
+	require.Equal(t, "Visvalingam-Whyatt", VisvalingamWhyatt.String())
+}
@@ -0,0 +1,515 @@
+package polygon
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// TriangulationMethod selects how Triangulate chooses the diagonals.
+type TriangulationMethod int
+
+const (
+	// EarClipping repeatedly cuts off a vertex whose triangle with its
+	// neighbours lies inside the polygon. It is fast, but the triangles
+	// can be long and thin.
+	EarClipping TriangulationMethod = iota
+	// ConstrainedDelaunay flips the diagonals of the ear clipping result
+	// until no triangle's circumcircle contains a vertex visible from
+	// inside it, which maximises the smallest angle.
+	ConstrainedDelaunay
+)
+
+func (m TriangulationMethod) String() string {
+	switch m {
+	case EarClipping:
+		return "ear clipping"
+	case ConstrainedDelaunay:
+		return "constrained Delaunay"
+	default:
+		return "unknown"
+	}
+}
+
+// Triangle holds the indices of three vertices, in counter-clockwise
+// order.
+type Triangle [3]int
+
+// Triangulate splits pg into triangles whose vertices are indices into pg,
+// n-2 of them for a polygon of n vertices. pg must pass Validate, whose
+// error is returned otherwise.
+func (pg Polygon) Triangulate(method TriangulationMethod) ([]Triangle, error) {
+	return WithHoles{Outer: pg}.Triangulate(method)
+}
+
+// Vertices returns the vertices of the outer ring followed by those of
+// each hole in turn, the slice that Triangulate indexes.
+func (p WithHoles) Vertices() []Point {
+	return slices.Concat(append([]Polygon{p.Outer}, p.Holes...)...)
+}
+
+// Triangulate splits p into triangles whose vertices are indices into
+// Vertices. Each hole is joined to the outer ring by a bridge edge, or
+// where the two touch, so that they can be triangulated as a single ring.
+// Holes touching each other all round close off parts of p, which are
+// triangulated as rings of their own. p must pass Validate, whose error is
+// returned otherwise.
+func (p WithHoles) Triangulate(method TriangulationMethod) ([]Triangle, error) {
+	if method != EarClipping && method != ConstrainedDelaunay {
+		return nil, fmt.Errorf("unknown triangulation method %d", method)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	vertices := p.Vertices()
+	rings := [][]int{ringIndices(p.Outer, 0, true)}
+	holes := make([][]int, len(p.Holes))
+	offset := len(p.Outer)
+	for i, hole := range p.Holes {
+		holes[i] = ringIndices(hole, offset, false)
+		offset += len(hole)
+	}
+
+	// Bridging the holes from right to left means that everything the ray
+	// from a hole can meet is already part of the ring.
+	slices.SortFunc(holes, func(a, b []int) int {
+		return cmp.Compare(vertices[b[rightmost(vertices, b)]].X, vertices[a[rightmost(vertices, a)]].X)
+	})
+	for _, hole := range holes {
+		var err error
+		if rings, err = bridge(vertices, rings, hole); err != nil {
+			return nil, err
+		}
+	}
+
+	var triangles []Triangle
+	for _, ring := range rings {
+		triangles = append(triangles, clipEars(vertices, ring)...)
+	}
+	if method == ConstrainedDelaunay {
+		constraints := make(map[[2]int]bool, len(vertices))
+		offset := 0
+		for _, r := range append([]Polygon{p.Outer}, p.Holes...) {
+			for i := range r {
+				constraints[edgeKey(offset+i, offset+(i+1)%len(r))] = true
+			}
+			offset += len(r)
+		}
+		flipToDelaunay(vertices, triangles, constraints)
+	}
+	return triangles, nil
+}
+
+// ringIndices returns the indices of the vertices of r, counted from
+// offset, ordered counter-clockwise if ccw is set and clockwise otherwise.
+func ringIndices(r Polygon, offset int, ccw bool) []int {
+	indices := make([]int, len(r))
+	for i := range indices {
+		indices[i] = offset + i
+	}
+	if (r.SignedArea() > 0) != ccw {
+		slices.Reverse(indices)
+	}
+	return indices
+}
+
+// rightmost returns the position in ring of the vertex with the largest X.
+func rightmost(vertices []Point, ring []int) int {
+	best := 0
+	for i, v := range ring {
+		if p, b := vertices[v], vertices[ring[best]]; p.X > b.X || p.X == b.X && p.Y < b.Y {
+			best = i
+		}
+	}
+	return best
+}
+
+// bridge splices the clockwise hole into the counter-clockwise ring of
+// rings that encloses it. A hole touching a ring is spliced in where they
+// touch. Otherwise it goes through an edge from the hole's rightmost
+// vertex M to a ring vertex visible from it, found as described by Eberly
+// in "Triangulation by Ear Clipping": the ray from M to the right meets
+// the enclosing ring at I, and the vertex wanted is the end P of the edge
+// hit unless ring vertices in the triangle M I P hide it, in which case it
+// is the one of those closest in angle to the ray.
+//
+// Where a hole touches the rings more than once it closes off part of the
+// polygon, and the joined ring is cut there into separate rings.
+func bridge(vertices []Point, rings [][]int, hole []int) ([][]int, error) {
+	r, joined := touch(vertices, rings, hole)
+	if r < 0 {
+		start := rightmost(vertices, hole)
+		m := vertices[hole[start]]
+
+		var err error
+		if r, err = enclosing(vertices, rings, m); err != nil {
+			return nil, err
+		}
+		ring := rings[r]
+		at, err := visible(vertices, ring, m)
+		if err != nil {
+			return nil, err
+		}
+		_, at = corner(vertices, rings[r:r+1], vertices[ring[at]], m)
+
+		joined = make([]int, 0, len(ring)+len(hole)+2)
+		joined = append(joined, ring[:at+1]...)
+		joined = append(joined, hole[start:]...)
+		joined = append(joined, hole[:start+1]...)
+		joined = append(joined, ring[at])
+		joined = append(joined, ring[at+1:]...)
+	}
+
+	result := slices.Delete(slices.Clone(rings), r, r+1)
+	return append(result, pinchOff(vertices, joined)...), nil
+}
+
+// touch splices hole into the ring it touches, if any, returning the
+// position of that ring in rings and the joined ring, or -1 and nil. The
+// hole goes in at the first point where a vertex of one lies on the
+// other, without a bridge.
+func touch(vertices []Point, rings [][]int, hole []int) (int, []int) {
+	for s, h := range hole {
+		x, next := vertices[h], vertices[hole[(s+1)%len(hole)]]
+
+		// The hole vertex is a ring vertex, which stands in for it.
+		if r, at := corner(vertices, rings, x, next); r >= 0 {
+			ring := rings[r]
+			return r, slices.Concat(ring[:at+1], hole[s+1:], hole[:s], ring[at:])
+		}
+
+		// The hole vertex lies on a ring edge.
+		for r, ring := range rings {
+			for i := range ring {
+				a, b := vertices[ring[i]], vertices[ring[(i+1)%len(ring)]]
+				if onSegment(x, a, b) {
+					return r, slices.Concat(ring[:i+1], hole[s:], hole[:s+1], ring[i+1:])
+				}
+			}
+		}
+	}
+
+	// A ring vertex lies on a hole edge.
+	for s := range hole {
+		u, v := vertices[hole[s]], vertices[hole[(s+1)%len(hole)]]
+		for _, ring := range rings {
+			for _, w := range ring {
+				if x := vertices[w]; x != u && x != v && onSegment(x, u, v) {
+					r, at := corner(vertices, rings, x, v)
+					ring := rings[r]
+					return r, slices.Concat(ring[:at+1], hole[s+1:], hole[:s+1], ring[at:])
+				}
+			}
+		}
+	}
+
+	return -1, nil
+}
+
+// corner finds the ring and position at which something at x heading
+// towards toward goes in. Where an earlier bridge ends or holes touch,
+// the rings pass through x more than once and their corners there nest,
+// so the one wanted is that whose outgoing edge is nearest clockwise of
+// the heading. It returns -1 and -1 if x is on no ring.
+func corner(vertices []Point, rings [][]int, x, toward Point) (int, int) {
+	direction := math.Atan2(toward.Y-x.Y, toward.X-x.X)
+	r, at, bestTurn := -1, -1, math.Inf(1)
+	for j, ring := range rings {
+		for i, v := range ring {
+			if vertices[v] != x {
+				continue
+			}
+			next := vertices[ring[(i+1)%len(ring)]]
+			turn := direction - math.Atan2(next.Y-x.Y, next.X-x.X)
+			if turn <= 0 {
+				turn += 2 * math.Pi
+			}
+			if turn < bestTurn {
+				r, at, bestTurn = j, i, turn
+			}
+		}
+	}
+	return r, at
+}
+
+// enclosing returns the position in rings of the ring around m, which
+// lies on none of them.
+func enclosing(vertices []Point, rings [][]int, m Point) (int, error) {
+	for r, ring := range rings {
+		pg := make(Polygon, len(ring))
+		for i, v := range ring {
+			pg[i] = vertices[v]
+		}
+		if pg.Locate(m) == Inside {
+			return r, nil
+		}
+	}
+	return -1, errors.New("no ring around a hole to bridge it to")
+}
+
+// visible returns the position in ring of a vertex that the hole vertex m,
+// the rightmost of its hole, can see.
+func visible(vertices []Point, ring []int, m Point) (int, error) {
+	hitX := math.Inf(1)
+	var p Point
+	for i := range ring {
+		a, b := vertices[ring[i]], vertices[ring[(i+1)%len(ring)]]
+		if (a.Y <= m.Y) == (b.Y <= m.Y) {
+			continue
+		}
+		x := a.X + (m.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+		if x < m.X || x >= hitX {
+			continue
+		}
+		hitX, p = x, a
+		if b.Y == m.Y || a.Y != m.Y && b.X > a.X {
+			p = b
+		}
+	}
+	if math.IsInf(hitX, 1) {
+		return -1, errors.New("no ring edge to the right of a hole to bridge it to")
+	}
+	hit := Point{hitX, m.Y}
+
+	// Only vertices whose corner of the ring faces M can see it.
+	at, bestTan := -1, math.Inf(1)
+	for i, v := range ring {
+		q := vertices[v]
+		if q.X < m.X || !inTriangle(m, hit, p, q) || !facing(vertices, ring, i, m) {
+			continue
+		}
+		tan := math.Abs(q.Y-m.Y) / (q.X - m.X)
+		if at < 0 || tan < bestTan || tan == bestTan && q.X < vertices[ring[at]].X {
+			at, bestTan = i, tan
+		}
+	}
+	if at < 0 {
+		return -1, errors.New("no vertex of the ring visible from a hole to bridge it to")
+	}
+	return at, nil
+}
+
+// pinchOff cuts ring where it passes through a point twice with corners
+// that overlap rather than nest, which happens where a hole touches the
+// rings twice and so closes off part of the polygon. Passing through
+// a → x → b and later c → x → d, the ring becomes b … c → x and
+// … a → x → d ….
+func pinchOff(vertices []Point, ring []int) [][]int {
+	seen := make(map[Point][]int)
+	for j, v := range ring {
+		x := vertices[v]
+		for _, i := range seen[x] {
+			if overlap(vertices, ring, i, j) {
+				inner := slices.Clone(ring[i+1 : j+1])
+				outer := slices.Concat(ring[:i+1], ring[j+1:])
+				return append(pinchOff(vertices, inner), pinchOff(vertices, outer)...)
+			}
+		}
+		seen[x] = append(seen[x], j)
+	}
+	return [][]int{ring}
+}
+
+// overlap reports whether the edge leaving position j of ring points into
+// the corner at position i, both at the same point.
+func overlap(vertices []Point, ring []int, i, j int) bool {
+	n := len(ring)
+	x := vertices[ring[i]]
+	angle := func(k int) float64 {
+		q := vertices[ring[(k+n)%n]]
+		return math.Atan2(q.Y-x.Y, q.X-x.X)
+	}
+	turn := func(from, to float64) float64 {
+		t := math.Mod(to-from, 2*math.Pi)
+		if t < 0 {
+			t += 2 * math.Pi
+		}
+		return t
+	}
+
+	// The corner at i turns counter-clockwise from its outgoing edge to
+	// its incoming one.
+	out := angle(i + 1)
+	t := turn(out, angle(j+1))
+	return t > 0 && t < turn(out, angle(i-1))
+}
+
+// facing reports whether q lies within the interior angle of the
+// counter-clockwise ring at position i.
+func facing(vertices []Point, ring []int, i int, q Point) bool {
+	prev := vertices[ring[(i+len(ring)-1)%len(ring)]]
+	p := vertices[ring[i]]
+	next := vertices[ring[(i+1)%len(ring)]]
+	if cross(prev, p, next) >= 0 {
+		return cross(prev, p, q) >= 0 && cross(p, next, q) >= 0
+	}
+	return cross(prev, p, q) >= 0 || cross(p, next, q) >= 0
+}
+
+// inTriangle reports whether q lies inside or on the triangle a, b, c,
+// which may be in either orientation.
+func inTriangle(a, b, c, q Point) bool {
+	d1, d2, d3 := cross(a, b, q), cross(b, c, q), cross(c, a, q)
+	negative := d1 < 0 || d2 < 0 || d3 < 0
+	positive := d1 > 0 || d2 > 0 || d3 > 0
+	return !(negative && positive)
+}
+
+// clipEars triangulates the counter-clockwise ring of vertex indices,
+// which may visit a vertex twice where a bridge joins a hole.
+func clipEars(vertices []Point, ring []int) []Triangle {
+	n := len(ring)
+	prev, next := make([]int, n), make([]int, n)
+	for i := range ring {
+		prev[i], next[i] = (i+n-1)%n, (i+1)%n
+	}
+	remove := func(i int) {
+		next[prev[i]], prev[next[i]] = next[i], prev[i]
+		n--
+	}
+	corner := func(i int) (Point, Point, Point) {
+		return vertices[ring[prev[i]]], vertices[ring[i]], vertices[ring[next[i]]]
+	}
+
+	isEar := func(i int) bool {
+		a, b, c := corner(i)
+		if cross(a, b, c) <= 0 {
+			return false
+		}
+		for j := next[next[i]]; j != prev[i]; j = next[j] {
+			q := vertices[ring[j]]
+			if q != a && q != b && q != c && inTriangle(a, b, c, q) {
+				return false
+			}
+		}
+		return true
+	}
+
+	triangles := make([]Triangle, 0, n-2)
+	i := 0
+	for tried := 0; n > 3; {
+		if isEar(i) {
+			triangles = append(triangles, Triangle{ring[prev[i]], ring[i], ring[next[i]]})
+			remove(i)
+			i, tried = next[i], 0
+			continue
+		}
+		if i, tried = next[i], tried+1; tried < n {
+			continue
+		}
+
+		// No ear left, which happens only where bridges or rounding leave
+		// vertices without area between them: drop one of those, or failing
+		// that cut off the sharpest convex corner regardless.
+		best, bestCross := -1, 0.0
+		for j, k := i, 0; k < n; j, k = next[j], k+1 {
+			a, b, c := corner(j)
+			if c := cross(a, b, c); c == 0 {
+				best, bestCross = j, 0
+				break
+			} else if c > bestCross {
+				best, bestCross = j, c
+			}
+		}
+		if best < 0 {
+			return triangles
+		}
+		if bestCross > 0 {
+			triangles = append(triangles, Triangle{ring[prev[best]], ring[best], ring[next[best]]})
+		}
+		remove(best)
+		i, tried = next[best], 0
+	}
+
+	if a, b, c := corner(i); cross(a, b, c) > 0 {
+		triangles = append(triangles, Triangle{ring[prev[i]], ring[i], ring[next[i]]})
+	}
+	return triangles
+}
+
+func edgeKey(a, b int) [2]int {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
+
+// flipToDelaunay replaces the diagonal shared by two triangles with the
+// other diagonal of their quadrilateral wherever the fourth vertex lies
+// inside the circumcircle of the other three, until none does. Edges in
+// constraints are never flipped.
+func flipToDelaunay(vertices []Point, triangles []Triangle, constraints map[[2]int]bool) {
+	adjacent := make(map[[2]int][]int, 3*len(triangles)/2)
+	var pending [][2]int
+	for t, tri := range triangles {
+		for e := range tri {
+			key := edgeKey(tri[e], tri[(e+1)%3])
+			if adjacent[key] = append(adjacent[key], t); len(adjacent[key]) == 2 {
+				pending = append(pending, key)
+			}
+		}
+	}
+	replace := func(key [2]int, from, to int) {
+		ts := adjacent[key]
+		if i := slices.Index(ts, from); i >= 0 {
+			ts[i] = to
+		}
+	}
+
+	for len(pending) > 0 {
+		key := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		ts := adjacent[key]
+		if len(ts) != 2 || constraints[key] {
+			continue
+		}
+
+		// Triangle t1 is a b c and t2 is b a d, both counter-clockwise.
+		t1, t2 := ts[0], ts[1]
+		tri := triangles[t1]
+		e := 0
+		for edgeKey(tri[e], tri[(e+1)%3]) != key {
+			e++
+		}
+		a, b, c := tri[e], tri[(e+1)%3], tri[(e+2)%3]
+		var d int
+		for _, v := range triangles[t2] {
+			if v != a && v != b {
+				d = v
+			}
+		}
+
+		pa, pb, pc, pd := vertices[a], vertices[b], vertices[c], vertices[d]
+		if !inCircumcircle(pa, pb, pc, pd) || cross(pc, pa, pd) <= 0 || cross(pd, pb, pc) <= 0 {
+			continue
+		}
+
+		triangles[t1] = Triangle{c, a, d}
+		triangles[t2] = Triangle{d, b, c}
+		delete(adjacent, key)
+		adjacent[edgeKey(c, d)] = []int{t1, t2}
+		replace(edgeKey(a, d), t2, t1)
+		replace(edgeKey(b, c), t1, t2)
+		pending = append(pending, edgeKey(a, d), edgeKey(d, b), edgeKey(b, c), edgeKey(c, a))
+	}
+}
+
+// inCircumcircle reports whether d lies clearly inside the circumcircle of
+// the counter-clockwise triangle a, b, c. Points within rounding error of
+// the circle count as outside, so that four cocircular points are not
+// flipped back and forth.
+func inCircumcircle(a, b, c, d Point) bool {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+	alift, blift, clift := adx*adx+ady*ady, bdx*bdx+bdy*bdy, cdx*cdx+cdy*cdy
+
+	det := alift*(bdx*cdy-cdx*bdy) + blift*(cdx*ady-adx*cdy) + clift*(adx*bdy-bdx*ady)
+	permanent := alift*(math.Abs(bdx*cdy)+math.Abs(cdx*bdy)) +
+		blift*(math.Abs(cdx*ady)+math.Abs(adx*cdy)) +
+		clift*(math.Abs(adx*bdy)+math.Abs(bdx*ady))
+	return det > 1e-12*permanent
+}
@@ -0,0 +1,282 @@
+package polygon
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireTriangulation checks that the triangles are counter-clockwise,
+// lie inside p and add up to its area, and for the constrained Delaunay
+// method that no flippable edge fails the circumcircle test.
+func requireTriangulation(t *testing.T, p WithHoles, triangles []Triangle, method TriangulationMethod) {
+	t.Helper()
+	vertices := p.Vertices()
+
+	total := 0.0
+	edges := make(map[[2]int][]Triangle)
+	for _, tri := range triangles {
+		a, b, c := vertices[tri[0]], vertices[tri[1]], vertices[tri[2]]
+		area := Polygon{a, b, c}.SignedArea()
+		require.Positive(t, area, "triangle %v", tri)
+		total += area
+
+		centroid := Point{(a.X + b.X + c.X) / 3, (a.Y + b.Y + c.Y) / 3}
+		require.Equal(t, Inside, p.Locate(centroid), "triangle %v", tri)
+
+		for e := range tri {
+			key := edgeKey(tri[e], tri[(e+1)%3])
+			edges[key] = append(edges[key], tri)
+		}
+	}
+	require.InDelta(t, p.Area(), total, 1e-9*p.Area())
+
+	for key, ts := range edges {
+		require.LessOrEqual(t, len(ts), 2, "edge %v", key)
+		if method != ConstrainedDelaunay || len(ts) != 2 {
+			continue
+		}
+		for _, tri := range ts[1] {
+			if tri != key[0] && tri != key[1] {
+				a, b, c := vertices[ts[0][0]], vertices[ts[0][1]], vertices[ts[0][2]]
+				require.False(t, inCircumcircle(a, b, c, vertices[tri]), "edge %v", key)
+			}
+		}
+	}
+}
+
+func TestTriangulate(t *testing.T) {
+	r := rand.New(rand.NewPCG(21, 22))
+	square := noisySquare(r, 100, 0.02)
+	comb := randomComb(r, 50)
+
+	tests := []struct {
+		name      string
+		polygon   WithHoles
+		triangles int
+	}{
+		{
+			name:      "triangle",
+			polygon:   WithHoles{Outer: Polygon{{0, 0}, {4, 0}, {2, 3}}},
+			triangles: 1,
+		},
+		{
+			name:      "square",
+			polygon:   WithHoles{Outer: unitSquare},
+			triangles: 2,
+		},
+		{
+			name:      "clockwise L shape",
+			polygon:   WithHoles{Outer: lShape.Reverse()},
+			triangles: 4,
+		},
+		{
+			name:      "collinear vertices",
+			polygon:   WithHoles{Outer: Polygon{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {3, 1}, {0, 1}}},
+			triangles: 4,
+		},
+		{
+			name:      "channel",
+			polygon:   WithHoles{Outer: channel},
+			triangles: len(channel) - 2,
+		},
+		{
+			name:      "noisy square",
+			polygon:   WithHoles{Outer: square},
+			triangles: len(square) - 2,
+		},
+		{
+			name:      "comb",
+			polygon:   WithHoles{Outer: comb},
+			triangles: len(comb) - 2,
+		},
+		{
+			name: "hole",
+			polygon: WithHoles{
+				Outer: Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+				Holes: []Polygon{{{4, 4}, {6, 4}, {6, 6}, {4, 6}}},
+			},
+			triangles: 8,
+		},
+		{
+			name: "holes hiding each other",
+			polygon: WithHoles{
+				Outer: Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+				Holes: []Polygon{
+					{{1, 4}, {3, 4}, {3, 6}, {1, 6}},
+					{{4, 3}, {6, 5}, {4, 7}},
+					{{7, 1}, {9, 1}, {9, 9}, {7, 9}},
+				},
+			},
+			triangles: 2 + 4 + 3 + 4 + 2*3,
+		},
+		{
+			name: "hole touching the outer ring",
+			polygon: WithHoles{
+				Outer: Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+				Holes: []Polygon{{{4, 4}, {10, 5}, {4, 6}}},
+			},
+			// The touching vertex splits the outer ring's right edge, so
+			// the hole adds no bridge.
+			triangles: 6,
+		},
+		{
+			name: "holes touching each other",
+			polygon: WithHoles{
+				Outer: Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+				Holes: []Polygon{
+					{{2, 2}, {4, 4}, {2, 4}},
+					{{4, 4}, {6, 6}, {4, 6}},
+					{{4, 4}, {6, 2}, {6, 4}},
+				},
+			},
+			triangles: 13,
+		},
+		{
+			name: "holes touching at a corner",
+			polygon: WithHoles{
+				Outer: Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+				Holes: []Polygon{
+					{{2, 2}, {4, 2}, {4, 4}, {2, 4}},
+					{{4, 4}, {6, 4}, {6, 6}, {4, 6}},
+				},
+			},
+			// The left hole is spliced in where it touches the right one.
+			triangles: 12,
+		},
+		{
+			name: "hole touching the edge of another",
+			polygon: WithHoles{
+				Outer: Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+				Holes: []Polygon{
+					{{2, 2}, {4, 2}, {4, 4}, {2, 4}},
+					{{4, 3}, {6, 2}, {6, 4}},
+				},
+			},
+			triangles: 12,
+		},
+		{
+			name: "holes closing off a region",
+			polygon: WithHoles{
+				Outer: Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+				Holes: []Polygon{
+					{{3, 4}, {4, 3}, {5, 4}, {4, 5}},
+					{{5, 4}, {6, 3}, {7, 4}, {6, 5}},
+					{{5, 6}, {6, 5}, {7, 6}, {6, 7}},
+					{{3, 6}, {4, 5}, {5, 6}, {4, 7}},
+				},
+			},
+			// The square the holes enclose is cut off as a ring of its
+			// own and split in two.
+			triangles: 18,
+		},
+		{
+			name: "hole in a concave ring",
+			polygon: WithHoles{
+				Outer: channel,
+				Holes: []Polygon{{{14, 6}, {18, 6}, {16, 7}}},
+			},
+			triangles: len(channel) - 2 + 3 + 2,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, method := range []TriangulationMethod{EarClipping, ConstrainedDelaunay} {
+			t.Run(tt.name+"/"+method.String(), func(t *testing.T) {
+				triangles, err := tt.polygon.Triangulate(method)
+				require.NoError(t, err)
+				require.Len(t, triangles, tt.triangles)
+				requireTriangulation(t, tt.polygon, triangles, method)
+			})
+		}
+	}
+}
+
+func TestTriangulateIndices(t *testing.T) {
+	triangles, err := unitSquare.Triangulate(EarClipping)
+	require.NoError(t, err)
+	require.Equal(t, []Triangle{{3, 0, 1}, {3, 1, 2}}, triangles)
+
+	// The square's corners are cocircular, so neither diagonal is better.
+	triangles, err = unitSquare.Triangulate(ConstrainedDelaunay)
+	require.NoError(t, err)
+	require.Equal(t, []Triangle{{3, 0, 1}, {3, 1, 2}}, triangles)
+
+	// Ear clipping fans out from one corner of this flat hexagon, which
+	// the Delaunay flips replace with fatter triangles.
+	hexagon := Polygon{{0, 0}, {4, 0}, {6, 1}, {4, 2}, {0, 2}, {-2, 1}}
+	clipped, err := hexagon.Triangulate(EarClipping)
+	require.NoError(t, err)
+	delaunay, err := hexagon.Triangulate(ConstrainedDelaunay)
+	require.NoError(t, err)
+	require.Greater(t, minAngle(hexagon, delaunay), minAngle(hexagon, clipped))
+
+	// Indices of holes follow those of the outer ring.
+	p := WithHoles{
+		Outer: Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		Holes: []Polygon{{{4, 4}, {6, 4}, {6, 6}, {4, 6}}},
+	}
+	require.Equal(t, []Point(slices.Concat(p.Outer, p.Holes[0])), p.Vertices())
+	triangles, err = p.Triangulate(EarClipping)
+	require.NoError(t, err)
+	used := make(map[int]bool)
+	for _, tri := range triangles {
+		for _, v := range tri {
+			used[v] = true
+		}
+	}
+	require.Len(t, used, 8)
+}
+
+func minAngle(pg Polygon, triangles []Triangle) float64 {
+	smallest := math.Pi
+	for _, tri := range triangles {
+		for e := range tri {
+			a, b, c := pg[tri[e]], pg[tri[(e+1)%3]], pg[tri[(e+2)%3]]
+			angle := math.Abs(math.Atan2(cross(a, b, c), (b.X-a.X)*(c.X-a.X)+(b.Y-a.Y)*(c.Y-a.Y)))
+			smallest = min(smallest, angle)
+		}
+	}
+	return smallest
+}
+
+func TestTriangulateRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(23, 24))
+
+	for i := 0; i < 50; i++ {
+		// A grid of square holes, shuffled and jittered so that the
+		// bridges have to find their way around each other.
+		p := WithHoles{Outer: noisySquare(r, 20, 0.01)}
+		for _, cell := range r.Perm(16) {
+			x, y := 1+2*float64(cell%4)+r.Float64()*0.3, 1+2*float64(cell/4)+r.Float64()*0.3
+			p.Holes = append(p.Holes, Polygon{{x, y}, {x + 1, y}, {x + 1, y + 1}, {x, y + 1}})
+		}
+
+		for _, method := range []TriangulationMethod{EarClipping, ConstrainedDelaunay} {
+			triangles, err := p.Triangulate(method)
+			require.NoError(t, err)
+			require.Len(t, triangles, len(p.Vertices())-2+2*len(p.Holes))
+			requireTriangulation(t, p, triangles, method)
+		}
+	}
+}
+
+func TestTriangulateErrors(t *testing.T) {
+	_, err := Polygon{{0, 0}, {1, 1}}.Triangulate(EarClipping)
+	require.ErrorIs(t, err, ErrTooFewVertices)
+
+	_, err = pentagram.Triangulate(ConstrainedDelaunay)
+	var intersection *SelfIntersectionError
+	require.True(t, errors.As(err, &intersection))
+
+	p := WithHoles{Outer: unitSquare, Holes: []Polygon{{{2, 2}, {3, 2}, {3, 3}}}}
+	_, err = p.Triangulate(EarClipping)
+	require.ErrorIs(t, err, ErrHoleOutside)
+
+	_, err = unitSquare.Triangulate(TriangulationMethod(7))
+	require.EqualError(t, err, "unknown triangulation method 7")
+}
@@ -0,0 +1,168 @@
+package polygon
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrTooFewVertices = errors.New("polygon needs at least 3 points")
+	ErrDegenerate     = errors.New("polygon has zero area: all vertices are collinear")
+)
+
+// DuplicateVertexError reports a vertex equal to the one after it, the
+// last vertex being followed by the first.
+type DuplicateVertexError struct {
+	Index int
+	Point Point
+}
+
+func (e *DuplicateVertexError) Error() string {
+	return fmt.Sprintf("vertex %d %v duplicates the next vertex", e.Index, e.Point)
+}
+
+// SelfIntersectionError reports two edges that cross, touch or overlap.
+// Edge i runs from vertex i to vertex i+1, wrapping to vertex 0.
+type SelfIntersectionError struct {
+	Edge1 int
+	Edge2 int
+}
+
+func (e *SelfIntersectionError) Error() string {
+	return fmt.Sprintf("edges %d and %d intersect", e.Edge1, e.Edge2)
+}
+
+// Validate reports the first problem that makes pg other than a simple
+// polygon with non-zero area: ErrTooFewVertices, a *DuplicateVertexError,
+// ErrDegenerate or a *SelfIntersectionError, checked in that order.
+// Edges meeting only at the vertex they share are fine; a ring touching
+// itself at any other point is reported as self-intersecting.
+func (pg Polygon) Validate() error {
+	n := len(pg)
+	if n < 3 {
+		return ErrTooFewVertices
+	}
+
+	for i := 0; i < n; i++ {
+		if pg[i] == pg[(i+1)%n] {
+			return &DuplicateVertexError{Index: i, Point: pg[i]}
+		}
+	}
+
+	if collinear(pg) {
+		return ErrDegenerate
+	}
+
+	var err error
+	pg.intersectingEdges(func(i, j int) bool {
+		err = &SelfIntersectionError{Edge1: i, Edge2: j}
+		return false
+	})
+	return err
+}
+
+// intersectingEdges calls fn with every pair of edges i < j that cross,
+// touch or overlap, ordered by i and then j, until fn returns false. Only
+// edges sharing a cell of an edgeGrid are compared, so a ring of n
+// vertices takes about linear rather than quadratic time. No vertex of pg
+// may equal the next.
+func (pg Polygon) intersectingEdges(fn func(i, j int) bool) {
+	n := len(pg)
+	grid := newEdgeGrid(pg)
+	for i := range pg {
+		grid.insert(i, (i+1)%n)
+	}
+
+	var hits []int
+	for i := 0; i < n; i++ {
+		hits = hits[:0]
+		grid.visit(pg[i], pg[(i+1)%n], func(e [2]int) bool {
+			if j := e[0]; j > i && pg.edgesIntersect(i, j) {
+				hits = append(hits, j)
+			}
+			return true
+		})
+
+		// An edge is visited once for every cell it shares with edge i.
+		slices.Sort(hits)
+		for _, j := range slices.Compact(hits) {
+			if !fn(i, j) {
+				return
+			}
+		}
+	}
+}
+
+// edgesIntersect reports whether edges i < j meet anywhere other than at
+// the vertex that neighbouring edges share.
+func (pg Polygon) edgesIntersect(i, j int) bool {
+	n := len(pg)
+	a1, a2 := pg[i], pg[(i+1)%n]
+	b1, b2 := pg[j], pg[(j+1)%n]
+
+	switch {
+	case j == i+1:
+		// Consecutive edges share a2 == b1 and only intersect elsewhere
+		// when the ring doubles back on itself.
+		return cross(a1, a2, b2) == 0 && folds(a1, a2, b2)
+	case i == 0 && j == n-1:
+		// The closing edge shares pg[0] with edge 0.
+		return cross(a1, a2, b1) == 0 && folds(a2, a1, b1)
+	default:
+		return segmentsIntersect(a1, a2, b1, b2)
+	}
+}
+
+// Repair removes duplicate vertices and orders the ring counter-clockwise.
+// It does not untangle self-intersections; the result is checked with
+// Validate and any remaining problem is returned with it.
+func (pg Polygon) Repair() (Polygon, error) {
+	result := make(Polygon, 0, len(pg))
+	for _, p := range pg {
+		if len(result) == 0 || result[len(result)-1] != p {
+			result = append(result, p)
+		}
+	}
+	for len(result) > 1 && result[len(result)-1] == result[0] {
+		result = result[:len(result)-1]
+	}
+
+	if result.SignedArea() < 0 {
+		result = result.Reverse()
+	}
+
+	return result, result.Validate()
+}
+
+func collinear(pg Polygon) bool {
+	for i := 2; i < len(pg); i++ {
+		if cross(pg[0], pg[1], pg[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// folds reports whether, with a, b and c collinear, the path a→b→c turns
+// back so the segments overlap beyond b.
+func folds(a, b, c Point) bool {
+	return (b.X-a.X)*(c.X-b.X)+(b.Y-a.Y)*(c.Y-b.Y) < 0
+}
+
+// segmentsIntersect reports whether the closed segments a1a2 and b1b2
+// share at least one point.
+func segmentsIntersect(a1, a2, b1, b2 Point) bool {
+	d1 := cross(b1, b2, a1)
+	d2 := cross(b1, b2, a2)
+	d3 := cross(a1, a2, b1)
+	d4 := cross(a1, a2, b2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+		((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	return onSegment(a1, b1, b2) || onSegment(a2, b1, b2) ||
+		onSegment(b1, a1, a2) || onSegment(b2, a1, a2)
+}
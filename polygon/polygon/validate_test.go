@@ -0,0 +1,166 @@
+package polygon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolygonValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		polygon  Polygon
+		expected error
+	}{
+		{
+			name:     "valid square",
+			polygon:  Polygon{{0, 0}, {1, 0}, {1, 1}, {0, 1}},
+			expected: nil,
+		},
+		{
+			name:     "valid clockwise concave",
+			polygon:  Polygon{{0, 0}, {0, 4}, {4, 4}, {4, 3}, {1, 3}, {1, 1}, {4, 1}, {4, 0}},
+			expected: nil,
+		},
+		{
+			name:     "valid with collinear vertex",
+			polygon:  Polygon{{0, 0}, {1, 0}, {2, 0}, {2, 2}},
+			expected: nil,
+		},
+		{
+			name:     "too few vertices",
+			polygon:  Polygon{{0, 0}, {1, 1}},
+			expected: ErrTooFewVertices,
+		},
+		{
+			name:     "consecutive duplicate",
+			polygon:  Polygon{{0, 0}, {1, 0}, {1, 0}, {1, 1}},
+			expected: &DuplicateVertexError{Index: 1, Point: Point{1, 0}},
+		},
+		{
+			name:     "closing duplicate",
+			polygon:  Polygon{{0, 0}, {1, 0}, {1, 1}, {0, 0}},
+			expected: &DuplicateVertexError{Index: 3, Point: Point{0, 0}},
+		},
+		{
+			name:     "collinear",
+			polygon:  Polygon{{0, 0}, {1, 1}, {3, 3}},
+			expected: ErrDegenerate,
+		},
+		{
+			name:     "bowtie",
+			polygon:  Polygon{{0, 0}, {2, 2}, {2, 0}, {0, 2}},
+			expected: &SelfIntersectionError{Edge1: 0, Edge2: 2},
+		},
+		{
+			name:     "touching at vertex",
+			polygon:  Polygon{{0, 0}, {2, 0}, {2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}, {0, 2}},
+			expected: &SelfIntersectionError{Edge1: 1, Edge2: 5},
+		},
+		{
+			name:     "vertex on another edge",
+			polygon:  Polygon{{0, 0}, {4, 0}, {4, 4}, {2, 0}, {0, 4}},
+			expected: &SelfIntersectionError{Edge1: 0, Edge2: 2},
+		},
+		{
+			name:     "spike doubling back",
+			polygon:  Polygon{{0, 0}, {4, 0}, {2, 0}, {2, 2}},
+			expected: &SelfIntersectionError{Edge1: 0, Edge2: 1},
+		},
+		{
+			name:     "closing edge doubling back",
+			polygon:  Polygon{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 6}},
+			expected: &SelfIntersectionError{Edge1: 2, Edge2: 4},
+		},
+		{
+			name:     "first edge overlaps closing edge",
+			polygon:  Polygon{{0, 0}, {0, 2}, {2, 2}, {0, 4}},
+			expected: &SelfIntersectionError{Edge1: 0, Edge2: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.polygon.Validate()
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestPolygonValidateErrorTypes(t *testing.T) {
+	err := Polygon{{0, 0}, {2, 2}, {2, 0}, {0, 2}}.Validate()
+
+	var intersection *SelfIntersectionError
+	require.True(t, errors.As(err, &intersection))
+	require.Equal(t, 0, intersection.Edge1)
+	require.Equal(t, 2, intersection.Edge2)
+	require.EqualError(t, err, "edges 0 and 2 intersect")
+
+	err = Polygon{{0, 0}, {0, 0}, {1, 0}, {1, 1}}.Validate()
+
+	var duplicate *DuplicateVertexError
+	require.True(t, errors.As(err, &duplicate))
+	require.Equal(t, 0, duplicate.Index)
+	require.EqualError(t, err, "vertex 0 {0 0} duplicates the next vertex")
+
+	require.ErrorIs(t, Polygon{{0, 0}, {1, 0}, {2, 0}}.Validate(), ErrDegenerate)
+}
+
+func TestPolygonRepair(t *testing.T) {
+	tests := []struct {
+		name      string
+		polygon   Polygon
+		expected  Polygon
+		shouldErr bool
+	}{
+		{
+			name:     "already valid",
+			polygon:  Polygon{{0, 0}, {1, 0}, {1, 1}, {0, 1}},
+			expected: Polygon{{0, 0}, {1, 0}, {1, 1}, {0, 1}},
+		},
+		{
+			name:     "duplicates removed",
+			polygon:  Polygon{{0, 0}, {0, 0}, {1, 0}, {1, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+			expected: Polygon{{0, 0}, {1, 0}, {1, 1}, {0, 1}},
+		},
+		{
+			name:     "clockwise reversed",
+			polygon:  Polygon{{0, 0}, {0, 1}, {1, 1}, {1, 0}},
+			expected: Polygon{{1, 0}, {1, 1}, {0, 1}, {0, 0}},
+		},
+		{
+			name:      "collapses to too few vertices",
+			polygon:   Polygon{{0, 0}, {0, 0}, {1, 1}, {0, 0}},
+			expected:  Polygon{{0, 0}, {1, 1}},
+			shouldErr: true,
+		},
+		{
+			name:      "self-intersection remains",
+			polygon:   Polygon{{0, 0}, {2, 2}, {2, 2}, {2, 0}, {0, 2}},
+			expected:  Polygon{{0, 0}, {2, 2}, {2, 0}, {0, 2}},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append(Polygon{}, tt.polygon...)
+
+			repaired, err := tt.polygon.Repair()
+			if tt.shouldErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Positive(t, repaired.SignedArea())
+			}
+
+			require.Equal(t, tt.expected, repaired)
+			require.Equal(t, original, tt.polygon)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package polygon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// ByteOrder is the byte order of Well-Known Binary, with the values used
+// for it in the first byte of each geometry.
+type ByteOrder byte
+
+const (
+	BigEndian    ByteOrder = 0
+	LittleEndian ByteOrder = 1
+)
+
+func (o ByteOrder) binary() binary.AppendByteOrder {
+	if o == BigEndian {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// WKB geometry types, and the flags that extended WKB adds to them.
+const (
+	wkbPolygon      = 3
+	wkbMultiPolygon = 6
+
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// WKB returns pg as a Well-Known Binary polygon without holes.
+func (pg Polygon) WKB(order ByteOrder) []byte {
+	return WithHoles{Outer: pg}.WKB(order)
+}
+
+// WKB returns p as a two-dimensional Well-Known Binary polygon, with each
+// ring closed by repeating its first vertex.
+func (p WithHoles) WKB(order ByteOrder) []byte {
+	return appendWKBPolygon(nil, order, p)
+}
+
+// WKB returns mp as a two-dimensional Well-Known Binary multi-polygon.
+func (mp MultiPolygon) WKB(order ByteOrder) []byte {
+	b := []byte{byte(order)}
+	b = order.binary().AppendUint32(b, wkbMultiPolygon)
+	b = order.binary().AppendUint32(b, uint32(len(mp)))
+	for _, p := range mp {
+		b = appendWKBPolygon(b, order, p)
+	}
+	return b
+}
+
+func appendWKBPolygon(b []byte, order ByteOrder, p WithHoles) []byte {
+	bo := order.binary()
+	rings := append([]Polygon{p.Outer}, p.Holes...)
+
+	b = append(b, byte(order))
+	b = bo.AppendUint32(b, wkbPolygon)
+	b = bo.AppendUint32(b, uint32(len(rings)))
+	for _, ring := range rings {
+		ring = closeRing(ring)
+		b = bo.AppendUint32(b, uint32(len(ring)))
+		for _, pt := range ring {
+			b = bo.AppendUint64(b, math.Float64bits(pt.X))
+			b = bo.AppendUint64(b, math.Float64bits(pt.Y))
+		}
+	}
+	return b
+}
+
+// ParseWKB parses a Well-Known Binary Polygon or MultiPolygon in either
+// byte order; a Polygon gives a single part. Rings must be closed, and the
+// closing vertex is dropped. Z and M values, in the ISO or the extended
+// (PostGIS) encoding, are accepted and ignored, as is an extended SRID.
+// Errors are *SyntaxError values locating the problem.
+func ParseWKB(data []byte) (MultiPolygon, error) {
+	p := wkbParser{data: data}
+
+	typ, dims, err := p.header()
+	if err != nil {
+		return nil, err
+	}
+
+	var result MultiPolygon
+	switch typ {
+	case wkbPolygon:
+		part, err := p.polygon(dims)
+		if err != nil {
+			return nil, err
+		}
+		result = MultiPolygon{part}
+
+	case wkbMultiPolygon:
+		n, err := p.count(1 + 4 + 4)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			at := p.pos
+			typ, dims, err := p.header()
+			if err != nil {
+				return nil, err
+			}
+			if typ != wkbPolygon {
+				return nil, p.errorf(at, "multi-polygon part %d has geometry type %d, expected %d", i, typ, wkbPolygon)
+			}
+			part, err := p.polygon(dims)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, part)
+		}
+
+	default:
+		return nil, p.errorf(1, "unsupported geometry type %d, expected %d or %d", typ, wkbPolygon, wkbMultiPolygon)
+	}
+
+	if p.pos < len(data) {
+		return nil, p.errorf(p.pos, "%d unexpected bytes after the end", len(data)-p.pos)
+	}
+	return result, nil
+}
+
+type wkbParser struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (p *wkbParser) errorf(offset int, format string, args ...any) error {
+	return &SyntaxError{Format: "WKB", Offset: offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *wkbParser) need(n int) error {
+	if len(p.data)-p.pos < n {
+		return p.errorf(p.pos, "need %d more bytes, found %d", n, len(p.data)-p.pos)
+	}
+	return nil
+}
+
+func (p *wkbParser) uint32() (uint32, error) {
+	if err := p.need(4); err != nil {
+		return 0, err
+	}
+	v := p.order.Uint32(p.data[p.pos:])
+	p.pos += 4
+	return v, nil
+}
+
+// count reads an element count, checking that the input could hold that
+// many elements of at least size bytes before anything is allocated.
+func (p *wkbParser) count(size int) (int, error) {
+	at := p.pos
+	n, err := p.uint32()
+	if err != nil {
+		return 0, err
+	}
+	if remaining := len(p.data) - p.pos; uint64(n)*uint64(size) > uint64(remaining) {
+		return 0, p.errorf(at, "count %d does not fit in the remaining %d bytes", n, remaining)
+	}
+	return int(n), nil
+}
+
+// header reads a byte order and a geometry type, returning the type
+// without dimension flags and the number of values in each position.
+func (p *wkbParser) header() (uint32, int, error) {
+	if err := p.need(1); err != nil {
+		return 0, 0, err
+	}
+	switch ByteOrder(p.data[p.pos]) {
+	case BigEndian:
+		p.order = binary.BigEndian
+	case LittleEndian:
+		p.order = binary.LittleEndian
+	default:
+		return 0, 0, p.errorf(p.pos, "invalid byte order %d, expected 0 or 1", p.data[p.pos])
+	}
+	p.pos++
+
+	at := p.pos
+	typ, err := p.uint32()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	dims := 2
+	if typ&ewkbZ != 0 {
+		dims++
+	}
+	if typ&ewkbM != 0 {
+		dims++
+	}
+	if typ&ewkbSRID != 0 {
+		if _, err := p.uint32(); err != nil {
+			return 0, 0, err
+		}
+	}
+	typ &^= ewkbZ | ewkbM | ewkbSRID
+
+	// ISO WKB adds 1000 for Z, 2000 for M and 3000 for both.
+	switch typ / 1000 {
+	case 0:
+	case 1, 2:
+		dims++
+	case 3:
+		dims += 2
+	default:
+		return 0, 0, p.errorf(at, "unsupported geometry type %d", typ)
+	}
+	return typ % 1000, dims, nil
+}
+
+func (p *wkbParser) polygon(dims int) (WithHoles, error) {
+	at := p.pos
+	n, err := p.count(4)
+	if err != nil {
+		return WithHoles{}, err
+	}
+	if n == 0 {
+		return WithHoles{}, p.errorf(at, "polygon has no rings")
+	}
+
+	var result WithHoles
+	for i := 0; i < n; i++ {
+		ring, err := p.ring(dims)
+		if err != nil {
+			return WithHoles{}, err
+		}
+		if i == 0 {
+			result.Outer = ring
+		} else {
+			result.Holes = append(result.Holes, ring)
+		}
+	}
+	return result, nil
+}
+
+func (p *wkbParser) ring(dims int) (Polygon, error) {
+	start := p.pos
+	n, err := p.count(8 * dims)
+	if err != nil {
+		return nil, err
+	}
+
+	ring := make(Polygon, n)
+	for i := range ring {
+		var coords [2]float64
+		for j := 0; j < dims; j++ {
+			v := math.Float64frombits(p.order.Uint64(p.data[p.pos:]))
+			if j < 2 {
+				if math.IsNaN(v) || math.IsInf(v, 0) {
+					return nil, p.errorf(p.pos, "coordinate %v is not finite", v)
+				}
+				coords[j] = v
+			}
+			p.pos += 8
+		}
+		ring[i] = Point{coords[0], coords[1]}
+	}
+
+	ring, msg := openRing(ring)
+	if msg != "" {
+		return nil, p.errorf(start, "%s", msg)
+	}
+	return ring, nil
+}
@@ -0,0 +1,232 @@
+package polygon
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	zero64  = "0000000000000000"
+	oneLE64 = "000000000000f03f"
+	oneBE64 = "3ff0000000000000"
+)
+
+var unitTriangle = Polygon{{0, 0}, {1, 0}, {1, 1}}
+
+func TestWKB(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    ByteOrder
+		expected string
+	}{
+		{
+			name:  "little endian",
+			order: LittleEndian,
+			expected: "01" + "03000000" + "01000000" + "04000000" +
+				zero64 + zero64 + oneLE64 + zero64 + oneLE64 + oneLE64 + zero64 + zero64,
+		},
+		{
+			name:  "big endian",
+			order: BigEndian,
+			expected: "00" + "00000003" + "00000001" + "00000004" +
+				zero64 + zero64 + oneBE64 + zero64 + oneBE64 + oneBE64 + zero64 + zero64,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := unitTriangle.WKB(tt.order)
+			require.Equal(t, tt.expected, hex.EncodeToString(b))
+
+			mp, err := ParseWKB(b)
+			require.NoError(t, err)
+			require.Equal(t, MultiPolygon{{Outer: unitTriangle}}, mp)
+
+			mp, err = ParseWKB(encodingFixture.WKB(tt.order))
+			require.NoError(t, err)
+			require.Equal(t, encodingFixture, mp)
+		})
+	}
+
+	empty, err := ParseWKB(MultiPolygon{}.WKB(LittleEndian))
+	require.NoError(t, err)
+	require.Empty(t, empty)
+}
+
+// wkbBuilder writes WKB by hand, for layouts the encoder does not produce.
+type wkbBuilder struct {
+	b     []byte
+	order binary.AppendByteOrder
+}
+
+func (w *wkbBuilder) header(order ByteOrder, typ uint32) *wkbBuilder {
+	w.order = order.binary()
+	w.b = append(w.b, byte(order))
+	return w.uint32(typ)
+}
+
+func (w *wkbBuilder) uint32(v uint32) *wkbBuilder {
+	w.b = w.order.AppendUint32(w.b, v)
+	return w
+}
+
+func (w *wkbBuilder) float64s(vs ...float64) *wkbBuilder {
+	for _, v := range vs {
+		w.b = w.order.AppendUint64(w.b, math.Float64bits(v))
+	}
+	return w
+}
+
+func TestParseWKBExtended(t *testing.T) {
+	expected := MultiPolygon{{Outer: unitTriangle}}
+
+	// PostGIS extended WKB with an SRID and Z values.
+	ewkb := new(wkbBuilder).header(LittleEndian, ewkbZ|ewkbSRID|wkbPolygon).uint32(4326).
+		uint32(1).uint32(4).float64s(0, 0, 5, 1, 0, 5, 1, 1, 6, 0, 0, 5).b
+	mp, err := ParseWKB(ewkb)
+	require.NoError(t, err)
+	require.Equal(t, expected, mp)
+
+	// ISO WKB with Z and M values.
+	iso := new(wkbBuilder).header(BigEndian, 3000+wkbPolygon).
+		uint32(1).uint32(4).float64s(0, 0, 5, 9, 1, 0, 5, 9, 1, 1, 6, 9, 0, 0, 5, 9).b
+	mp, err = ParseWKB(iso)
+	require.NoError(t, err)
+	require.Equal(t, expected, mp)
+
+	// Parts of a multi-polygon may use their own byte order.
+	mixed := new(wkbBuilder).header(BigEndian, wkbMultiPolygon).uint32(2).b
+	mixed = append(mixed, unitTriangle.WKB(LittleEndian)...)
+	mixed = append(mixed, unitTriangle.WKB(BigEndian)...)
+	mp, err = ParseWKB(mixed)
+	require.NoError(t, err)
+	require.Equal(t, MultiPolygon{{Outer: unitTriangle}, {Outer: unitTriangle}}, mp)
+}
+
+func TestParseWKBErrors(t *testing.T) {
+	valid := unitTriangle.WKB(LittleEndian)
+
+	tests := []struct {
+		name   string
+		input  []byte
+		offset int
+		msg    string
+	}{
+		{
+			name:   "empty",
+			input:  nil,
+			offset: 0,
+			msg:    "need 1 more bytes, found 0",
+		},
+		{
+			name:   "bad byte order",
+			input:  append([]byte{2}, valid[1:]...),
+			offset: 0,
+			msg:    "invalid byte order 2",
+		},
+		{
+			name:   "point",
+			input:  new(wkbBuilder).header(LittleEndian, 1).float64s(0, 0).b,
+			offset: 1,
+			msg:    "unsupported geometry type 1",
+		},
+		{
+			name:   "unknown dimension",
+			input:  new(wkbBuilder).header(LittleEndian, 4003).b,
+			offset: 1,
+			msg:    "unsupported geometry type 4003",
+		},
+		{
+			name:   "no rings",
+			input:  new(wkbBuilder).header(LittleEndian, wkbPolygon).uint32(0).b,
+			offset: 5,
+			msg:    "polygon has no rings",
+		},
+		{
+			name:   "truncated",
+			input:  valid[:len(valid)-3],
+			offset: 9,
+			msg:    "count 4 does not fit in the remaining 61 bytes",
+		},
+		{
+			name:   "huge count",
+			input:  new(wkbBuilder).header(LittleEndian, wkbPolygon).uint32(math.MaxUint32).b,
+			offset: 5,
+			msg:    "count 4294967295 does not fit",
+		},
+		{
+			name:   "unclosed ring",
+			input:  new(wkbBuilder).header(LittleEndian, wkbPolygon).uint32(1).uint32(4).float64s(0, 0, 1, 0, 1, 1, 0, 1).b,
+			offset: 9,
+			msg:    "ring is not closed",
+		},
+		{
+			name:   "short ring",
+			input:  new(wkbBuilder).header(LittleEndian, wkbPolygon).uint32(1).uint32(3).float64s(0, 0, 1, 0, 0, 0).b,
+			offset: 9,
+			msg:    "ring needs at least 4 positions, got 3",
+		},
+		{
+			name:   "not a number",
+			input:  new(wkbBuilder).header(LittleEndian, wkbPolygon).uint32(1).uint32(4).float64s(0, 0, 1, math.NaN(), 1, 1, 0, 0).b,
+			offset: 37,
+			msg:    "coordinate NaN is not finite",
+		},
+		{
+			name:   "part is not a polygon",
+			input:  append(new(wkbBuilder).header(LittleEndian, wkbMultiPolygon).uint32(1).b, new(wkbBuilder).header(LittleEndian, wkbMultiPolygon).uint32(0).b...),
+			offset: 9,
+			msg:    "multi-polygon part 0 has geometry type 6, expected 3",
+		},
+		{
+			name:   "trailing bytes",
+			input:  append(valid, 0, 0),
+			offset: len(valid),
+			msg:    "2 unexpected bytes after the end",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWKB(tt.input)
+			var syntax *SyntaxError
+			require.ErrorAs(t, err, &syntax)
+			require.Equal(t, "WKB", syntax.Format)
+			require.Equal(t, tt.offset, syntax.Offset)
+			require.Contains(t, syntax.Msg, tt.msg)
+		})
+	}
+}
+
+func FuzzWKB(f *testing.F) {
+	f.Add(encodingFixture.WKB(LittleEndian))
+	f.Add(encodingFixture.WKB(BigEndian))
+	f.Add(encodingFixture[0].WKB(BigEndian))
+	f.Add(new(wkbBuilder).header(LittleEndian, ewkbZ|ewkbSRID|wkbPolygon).uint32(4326).
+		uint32(1).uint32(4).float64s(0, 0, 5, 1, 0, 5, 1, 1, 6, 0, 0, 5).b)
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		mp, err := ParseWKB(b)
+		if err != nil {
+			var syntax *SyntaxError
+			if !errors.As(err, &syntax) || syntax.Offset < 0 || syntax.Offset > len(b) {
+				t.Fatalf("unexpected error %#v", err)
+			}
+			return
+		}
+
+		for _, order := range []ByteOrder{LittleEndian, BigEndian} {
+			again, err := ParseWKB(mp.WKB(order))
+			require.NoError(t, err)
+			require.Equal(t, len(mp), len(again))
+			if len(mp) > 0 {
+				require.Equal(t, mp, again)
+			}
+		}
+	})
+}
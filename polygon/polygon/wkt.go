@@ -0,0 +1,234 @@
+package polygon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WKT returns pg as a Well-Known Text polygon without holes.
+func (pg Polygon) WKT() string {
+	return WithHoles{Outer: pg}.WKT()
+}
+
+// WKT returns p as a Well-Known Text polygon, "POLYGON ((x y, ...), ...)",
+// with each ring closed by repeating its first vertex.
+func (p WithHoles) WKT() string {
+	var b strings.Builder
+	b.WriteString("POLYGON ")
+	writeWKTPolygon(&b, p)
+	return b.String()
+}
+
+// WKT returns mp as a Well-Known Text multi-polygon, or "MULTIPOLYGON
+// EMPTY" when it has no parts.
+func (mp MultiPolygon) WKT() string {
+	if len(mp) == 0 {
+		return "MULTIPOLYGON EMPTY"
+	}
+
+	var b strings.Builder
+	b.WriteString("MULTIPOLYGON (")
+	for i, p := range mp {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		writeWKTPolygon(&b, p)
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func writeWKTPolygon(b *strings.Builder, p WithHoles) {
+	b.WriteString("(")
+	for i, ring := range append([]Polygon{p.Outer}, p.Holes...) {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(")
+		for j, pt := range closeRing(ring) {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(strconv.FormatFloat(pt.X, 'f', -1, 64))
+			b.WriteString(" ")
+			b.WriteString(strconv.FormatFloat(pt.Y, 'f', -1, 64))
+		}
+		b.WriteString(")")
+	}
+	b.WriteString(")")
+}
+
+// ParseWKT parses a Well-Known Text POLYGON or MULTIPOLYGON, in any case
+// and with any spacing; a POLYGON gives a single part. Rings must be
+// closed, and the closing vertex is dropped. Z and M values are accepted
+// and ignored. Errors are *SyntaxError values locating the problem.
+func ParseWKT(s string) (MultiPolygon, error) {
+	p := wktParser{s: s, dims: 2}
+
+	tag, at := p.word()
+	tag = strings.ToUpper(tag)
+	if tag != "POLYGON" && tag != "MULTIPOLYGON" {
+		return nil, p.errorf(at, "expected POLYGON or MULTIPOLYGON, found %q", tag)
+	}
+
+	// An optional dimension, then an optional EMPTY.
+	word, at := p.word()
+	switch strings.ToUpper(word) {
+	case "Z", "M":
+		p.dims = 3
+		word, at = p.word()
+	case "ZM":
+		p.dims = 4
+		word, at = p.word()
+	}
+
+	var result MultiPolygon
+	switch strings.ToUpper(word) {
+	case "EMPTY":
+	case "":
+		var err error
+		if tag == "POLYGON" {
+			var part WithHoles
+			part, err = p.polygon()
+			result = MultiPolygon{part}
+		} else {
+			err = p.list(func() error {
+				part, err := p.polygon()
+				result = append(result, part)
+				return err
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.errorf(at, "expected ( or EMPTY, found %q", word)
+	}
+
+	if p.skipSpace(); p.pos < len(p.s) {
+		return nil, p.errorf(p.pos, "unexpected %q after the end", p.s[p.pos:])
+	}
+	return result, nil
+}
+
+type wktParser struct {
+	s    string
+	pos  int
+	dims int
+}
+
+func (p *wktParser) errorf(offset int, format string, args ...any) error {
+	return &SyntaxError{Format: "WKT", Offset: offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// word returns the run of letters at the next non-space position, which
+// may be empty, and where it starts.
+func (p *wktParser) word() (string, int) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) && ('a' <= p.s[p.pos]|0x20 && p.s[p.pos]|0x20 <= 'z') {
+		p.pos++
+	}
+	return p.s[start:p.pos], start
+}
+
+func (p *wktParser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return p.errorf(p.pos, "expected %q, found the end", c)
+	}
+	if p.s[p.pos] != c {
+		return p.errorf(p.pos, "expected %q, found %q", c, p.s[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+// list parses "(item, item, ...)" with at least one item.
+func (p *wktParser) list(item func() error) error {
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		if p.skipSpace(); p.pos < len(p.s) && p.s[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		return p.expect(')')
+	}
+}
+
+func (p *wktParser) polygon() (WithHoles, error) {
+	var result WithHoles
+	first := true
+	err := p.list(func() error {
+		ring, err := p.ring()
+		if first {
+			result.Outer, first = ring, false
+		} else {
+			result.Holes = append(result.Holes, ring)
+		}
+		return err
+	})
+	return result, err
+}
+
+func (p *wktParser) ring() (Polygon, error) {
+	p.skipSpace()
+	start := p.pos
+
+	var ring Polygon
+	err := p.list(func() error {
+		var coords [4]float64
+		for i := 0; i < p.dims; i++ {
+			c, err := p.number()
+			if err != nil {
+				return err
+			}
+			coords[i] = c
+		}
+		ring = append(ring, Point{coords[0], coords[1]})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ring, msg := openRing(ring)
+	if msg != "" {
+		return nil, p.errorf(start, "%s", msg)
+	}
+	return ring, nil
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+
+	text := p.s[start:p.pos]
+	if text == "" {
+		if start >= len(p.s) {
+			return 0, p.errorf(start, "expected a number, found the end")
+		}
+		return 0, p.errorf(start, "expected a number, found %q", p.s[start])
+	}
+
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, p.errorf(start, "invalid number %q", text)
+	}
+	return f, nil
+}
@@ -0,0 +1,153 @@
+package polygon
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// encodingFixture has a part with a hole and a part without, oriented as
+// GeoJSON requires.
+var encodingFixture = MultiPolygon{
+	{
+		Outer: Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		Holes: []Polygon{{{2, 2}, {2, 4}, {4, 4}, {4, 2}}},
+	},
+	{
+		Outer: Polygon{{20, 0}, {22, -3.25}, {25.5, 0}},
+	},
+}
+
+func TestWKT(t *testing.T) {
+	require.Equal(t, "POLYGON ((0 0, 4 0, 2 3, 0 0))", Polygon{{0, 0}, {4, 0}, {2, 3}}.WKT())
+	require.Equal(t,
+		"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 4, 4 4, 4 2, 2 2))",
+		encodingFixture[0].WKT())
+	require.Equal(t,
+		"MULTIPOLYGON (((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 4, 4 4, 4 2, 2 2)), ((20 0, 22 -3.25, 25.5 0, 20 0)))",
+		encodingFixture.WKT())
+	require.Equal(t, "MULTIPOLYGON EMPTY", MultiPolygon{}.WKT())
+	require.Equal(t, "POLYGON ((0.1 -0, 0.0000001 100000000000000000000, 3 3, 0.1 -0))", Polygon{{0.1, math.Copysign(0, -1)}, {1e-7, 1e20}, {3, 3}}.WKT())
+}
+
+func TestParseWKT(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected MultiPolygon
+	}{
+		{
+			name:     "polygon",
+			input:    "POLYGON ((0 0, 4 0, 2 3, 0 0))",
+			expected: MultiPolygon{{Outer: Polygon{{0, 0}, {4, 0}, {2, 3}}}},
+		},
+		{
+			name:     "polygon with hole",
+			input:    encodingFixture[0].WKT(),
+			expected: encodingFixture[:1],
+		},
+		{
+			name:     "multi-polygon",
+			input:    encodingFixture.WKT(),
+			expected: encodingFixture,
+		},
+		{
+			name:     "lower case and loose spacing",
+			input:    "\n polygon(( 0 0,4 0 ,\t2 3,0 0 ) ) ",
+			expected: MultiPolygon{{Outer: Polygon{{0, 0}, {4, 0}, {2, 3}}}},
+		},
+		{
+			name:     "signs and exponents",
+			input:    "POLYGON ((-1.5e2 +0, 1E-3 0, 0 .5, -1.5e2 0))",
+			expected: MultiPolygon{{Outer: Polygon{{-150, 0}, {0.001, 0}, {0, 0.5}}}},
+		},
+		{
+			name:     "z values ignored",
+			input:    "POLYGON Z ((0 0 7, 4 0 7, 2 3 8, 0 0 7))",
+			expected: MultiPolygon{{Outer: Polygon{{0, 0}, {4, 0}, {2, 3}}}},
+		},
+		{
+			name:     "zm values ignored",
+			input:    "MULTIPOLYGON ZM (((0 0 7 1, 4 0 7 2, 2 3 8 3, 0 0 7 1)))",
+			expected: MultiPolygon{{Outer: Polygon{{0, 0}, {4, 0}, {2, 3}}}},
+		},
+		{
+			name:     "empty polygon",
+			input:    "POLYGON EMPTY",
+			expected: nil,
+		},
+		{
+			name:     "empty multi-polygon",
+			input:    "multipolygon empty",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp, err := ParseWKT(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, mp)
+		})
+	}
+}
+
+func TestParseWKTErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		offset int
+		msg    string
+	}{
+		{name: "empty", input: "", offset: 0, msg: `expected POLYGON or MULTIPOLYGON, found ""`},
+		{name: "other geometry", input: "LINESTRING (0 0, 1 1)", offset: 0, msg: `expected POLYGON or MULTIPOLYGON, found "LINESTRING"`},
+		{name: "unknown word", input: "POLYGON FULL", offset: 8, msg: `expected ( or EMPTY, found "FULL"`},
+		{name: "missing parenthesis", input: "POLYGON (0 0, 1 0, 1 1, 0 0)", offset: 9, msg: `expected '(', found '0'`},
+		{name: "unclosed ring", input: "POLYGON ((0 0, 4 0, 2 3, 0 1))", offset: 9, msg: "ring is not closed"},
+		{name: "short ring", input: "POLYGON ((0 0, 4 0, 0 0))", offset: 9, msg: "ring needs at least 4 positions, got 3"},
+		{name: "missing coordinate", input: "POLYGON ((0 0, 4, 2 3, 0 0))", offset: 16, msg: "expected a number, found ','"},
+		{name: "bad number", input: "POLYGON ((0 0, 4 0, 2 3e, 0 0))", offset: 22, msg: `invalid number "3e"`},
+		{name: "number out of range", input: "POLYGON ((0 0, 4 0, 2 3e999, 0 0))", offset: 22, msg: `invalid number "3e999"`},
+		{name: "truncated", input: "POLYGON ((0 0, 4 0, 2 3, 0 0)", offset: 29, msg: "expected ')', found the end"},
+		{name: "trailing text", input: "POLYGON ((0 0, 4 0, 2 3, 0 0)) x", offset: 31, msg: `unexpected "x" after the end`},
+		{name: "second ring", input: "POLYGON ((0 0, 4 0, 2 3, 0 0), (1 1, 2 1, 1 1))", offset: 31, msg: "at least 4 positions"},
+		{name: "polygon in multi-polygon", input: "MULTIPOLYGON ((0 0, 4 0, 2 3, 0 0))", offset: 15, msg: `expected '(', found '0'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWKT(tt.input)
+			var syntax *SyntaxError
+			require.ErrorAs(t, err, &syntax)
+			require.Equal(t, "WKT", syntax.Format)
+			require.Equal(t, tt.offset, syntax.Offset)
+			require.Contains(t, syntax.Msg, tt.msg)
+		})
+	}
+}
+
+func FuzzWKT(f *testing.F) {
+	f.Add(encodingFixture.WKT())
+	f.Add(encodingFixture[0].WKT())
+	f.Add("POLYGON Z ((0 0 7, 4 0 7, 2 3 8, 0 0 7))")
+	f.Add("polygon((1e300 -1e-300,0 0,.5 -.5,1e300 -1e-300))")
+	f.Add("MULTIPOLYGON EMPTY")
+	f.Add("POLYGON ((0 0, 4 0, 2 3, 0 1))")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		mp, err := ParseWKT(s)
+		if err != nil {
+			var syntax *SyntaxError
+			if !errors.As(err, &syntax) || syntax.Offset < 0 || syntax.Offset > len(s) {
+				t.Fatalf("unexpected error %#v", err)
+			}
+			return
+		}
+
+		again, err := ParseWKT(mp.WKT())
+		require.NoError(t, err)
+		require.Equal(t, mp, again)
+	})
+}